@@ -1,14 +1,20 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os/signal"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/labasubagia/realworld-backend/internal/adapter/handler"
 	"github.com/labasubagia/realworld-backend/internal/adapter/logger"
 	"github.com/labasubagia/realworld-backend/internal/adapter/repository"
 	"github.com/labasubagia/realworld-backend/internal/core/service"
 	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/tracing"
 	"github.com/spf13/cobra"
 )
 
@@ -22,6 +28,9 @@ func init() {
 	serverCmd.Flags().Bool("prod", config.IsProduction(), "use for production")
 	serverCmd.Flags().StringVarP(&config.ServerType, "server", "s", config.ServerType, fmt.Sprintf("server type in (%s)", serverTypeStr))
 	serverCmd.Flags().IntVarP(&config.ServerPort, "port", "p", config.ServerPort, "server port number")
+	serverCmd.Flags().IntVar(&config.AdminPort, "admin-port", config.AdminPort, "metrics and debug vars port number")
+	serverCmd.Flags().IntVar(&config.GrpcPort, "grpc-port", config.GrpcPort, "grpc port number when serve all")
+	serverCmd.Flags().DurationVar(&config.ShutdownTimeout, "shutdown-timeout", config.ShutdownTimeout, "drain timeout before forced stop")
 	serverCmd.Flags().StringVarP(&config.DBType, "database", "d", config.DBType, fmt.Sprintf("database type in (%s)", dbTypeStr))
 	serverCmd.Flags().StringVarP(&config.LogType, "log", "l", config.LogType, fmt.Sprintf("log type in (%s)", logTypeStr))
 }
@@ -40,6 +49,13 @@ var serverCmd = &cobra.Command{
 		logger := logger.NewLogger(config)
 		logger.Info().Msgf("use logger %s", config.LogType)
 
+		// tracing
+		shutdownTracing, err := tracing.Setup(config)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("failed to setup tracing")
+		}
+		defer shutdownTracing(context.Background())
+
 		// repository
 		repo, err := repository.NewRepository(config, logger)
 		if err != nil {
@@ -52,10 +68,54 @@ var serverCmd = &cobra.Command{
 			logger.Fatal().Err(err).Msg("failed to load service")
 		}
 
-		logger.Info().Msgf("%s server listen to port %d", config.ServerType, config.ServerPort)
+		// servers and background workers stop together on signal
+		ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer cancel()
+
+		// workers finish current batch before repository closed,
+		// webhook delivery run inside outbox relay so it is waited too
+		var workers sync.WaitGroup
+		workers.Add(2)
+
+		// relay outbox events until server stopped
+		go func() {
+			defer workers.Done()
+			service.Outbox().Run(ctx)
+		}()
+
+		// purge expired idempotency keys
+		go func() {
+			defer workers.Done()
+			ticker := time.NewTicker(time.Hour)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if _, err := service.Idempotency().Purge(ctx); err != nil {
+						logger.Error().Err(err).Msg("failed to purge idempotency keys")
+					}
+				}
+			}
+		}()
+
+		logger.Info().Msgf("use server %s", config.ServerType)
 		server := handler.NewServer(config, service, logger)
-		if server.Start(); err != nil {
-			logger.Fatal().Err(err).Msg("failed to load service")
+		if err := server.Start(ctx); err != nil {
+			logger.Error().Err(err).Msg("failed to serve")
+		}
+
+		// server may exit on error without signal
+		cancel()
+		workers.Wait()
+
+		closeCtx, closeCancel := context.WithTimeout(context.Background(), config.DrainTimeout())
+		defer closeCancel()
+		if err := repo.Close(closeCtx); err != nil {
+			logger.Error().Err(err).Msg("failed to close repository")
+			return
 		}
+		logger.Info().Msg("server exiting")
 	},
 }
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	grpc_api "github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/api"
+	"github.com/labasubagia/realworld-backend/internal/adapter/handler/restful"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+)
+
+const TypeAll = "all"
+
+// allServer serve restful on server port and grpc on grpc port,
+// both share the same service and repository
+type allServer struct {
+	servers      []port.Server
+	drainTimeout time.Duration
+}
+
+func NewAllServer(config util.Config, service port.Service, logger port.Logger) port.Server {
+	grpcConfig := config
+	grpcConfig.ServerPort = config.GrpcPort
+	grpcConfig.AdminPort = 0 // metrics and probes served on admin port of restful
+	return &allServer{
+		servers: []port.Server{
+			restful.NewServer(config, service, logger),
+			grpc_api.NewServer(grpcConfig, service, logger),
+		},
+		drainTimeout: config.DrainTimeout(),
+	}
+}
+
+// Start every server, one server failed stop the others,
+// server not stopped within drain timeout after stop is abandoned
+func (s *allServer) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan error, len(s.servers))
+	for _, server := range s.servers {
+		go func(server port.Server) {
+			results <- server.Start(ctx)
+			cancel()
+		}(server)
+	}
+
+	errs := []error{}
+	stopping := ctx.Done()
+	var timeout <-chan time.Time
+	for remaining := len(s.servers); remaining > 0; {
+		select {
+		case err := <-results:
+			remaining--
+			errs = append(errs, err)
+		case <-stopping:
+			stopping = nil
+			timer := time.NewTimer(s.drainTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		case <-timeout:
+			errs = append(errs, fmt.Errorf("%d server not stopped within drain timeout %s", remaining, s.drainTimeout))
+			return errors.Join(errs...)
+		}
+	}
+	return errors.Join(errs...)
+}
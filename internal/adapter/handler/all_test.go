@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer run start instead of serving, count stop by context
+type fakeServer struct {
+	start   func(context.Context) error
+	stopped atomic.Bool
+}
+
+func (s *fakeServer) Start(ctx context.Context) error {
+	err := s.start(ctx)
+	s.stopped.Store(true)
+	return err
+}
+
+func serveUntilDone(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func TestAllServerStopOthersOnFailure(t *testing.T) {
+	errListen := errors.New("address already in use")
+	failed := &fakeServer{start: func(context.Context) error { return errListen }}
+	running := &fakeServer{start: serveUntilDone}
+	server := &allServer{
+		servers:      []port.Server{running, failed},
+		drainTimeout: time.Second,
+	}
+
+	err := server.Start(context.Background())
+	require.ErrorIs(t, err, errListen)
+	require.True(t, failed.stopped.Load())
+	require.True(t, running.stopped.Load())
+}
+
+func TestAllServerStopOnCancel(t *testing.T) {
+	servers := []*fakeServer{{start: serveUntilDone}, {start: serveUntilDone}}
+	server := &allServer{
+		servers:      []port.Server{servers[0], servers[1]},
+		drainTimeout: time.Second,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- server.Start(ctx) }()
+	cancel()
+
+	select {
+	case err := <-result:
+		require.Nil(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("start not returned after cancel")
+	}
+	for _, s := range servers {
+		require.True(t, s.stopped.Load())
+	}
+}
+
+func TestAllServerDrainTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	// hung server ignore context, e.g. connection never drained
+	hung := &fakeServer{start: func(context.Context) error {
+		<-release
+		return nil
+	}}
+	running := &fakeServer{start: serveUntilDone}
+	drainTimeout := 100 * time.Millisecond
+	server := &allServer{
+		servers:      []port.Server{hung, running},
+		drainTimeout: drainTimeout,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() { result <- server.Start(ctx) }()
+	cancel()
+	stopAt := time.Now()
+
+	select {
+	case err := <-result:
+		require.NotNil(t, err)
+		require.ErrorContains(t, err, "drain timeout")
+		require.Less(t, time.Since(stopAt), drainTimeout+500*time.Millisecond)
+	case <-time.After(5 * drainTimeout):
+		t.Fatal("start not returned within drain timeout")
+	}
+	require.True(t, running.stopped.Load())
+	require.False(t, hung.stopped.Load())
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	grpc_api "github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/api"
+	"github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/gateway"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+)
+
+// NewGatewayServer serve grpc on grpc port and restful json proxy of it on server port,
+// metrics and probes stay on admin port of grpc server
+func NewGatewayServer(config util.Config, service port.Service, logger port.Logger) port.Server {
+	grpcConfig := config
+	grpcConfig.ServerPort = config.GrpcPort
+	return &allServer{
+		servers: []port.Server{
+			gateway.NewServer(config, logger),
+			grpc_api.NewServer(grpcConfig, service, logger),
+		},
+	}
+}
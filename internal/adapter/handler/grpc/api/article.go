@@ -24,37 +24,29 @@ func (server *Server) ListArticle(ctx context.Context, req *pb.FilterArticleRequ
 	}
 
 	arg := port.ListArticleParams{
-		Tags:           []string{},
-		AuthorNames:    []string{},
-		FavoritedNames: []string{},
+		Tags:           req.GetTag(),
+		TagMode:        req.GetTagMode(),
+		AuthorNames:    req.GetAuthor(),
+		FavoritedNames: req.GetFavorited(),
+		Sort:           req.GetSort(),
+		Cursor:         req.GetCursor(),
 		AuthArg:        auth,
 		Offset:         offset,
 		Limit:          limit,
 	}
-	if req.GetTag() != "" {
-		arg.Tags = append(arg.Tags, req.GetTag())
+	if req.CreatedAfter != nil {
+		arg.CreatedAfter = req.GetCreatedAfter().AsTime()
 	}
-	if req.GetAuthor() != "" {
-		arg.AuthorNames = append(arg.AuthorNames, req.GetAuthor())
-	}
-	if req.GetFavorited() != "" {
-		arg.FavoritedNames = append(arg.FavoritedNames, req.GetFavorited())
+	if req.CreatedBefore != nil {
+		arg.CreatedBefore = req.GetCreatedBefore().AsTime()
 	}
 
-	articles, err := server.service.Article().List(ctx, arg)
+	result, err := server.service.Article().List(ctx, arg)
 	if err != nil {
 		return nil, handleError(err)
 	}
 
-	res := &pb.ArticlesResponse{
-		Articles: []*pb.Article{},
-		Count:    int64(len(articles)),
-	}
-	for _, article := range articles {
-		res.Articles = append(res.Articles, serializeArticle(article))
-	}
-
-	return res, nil
+	return serializeArticles(result), nil
 }
 
 func (server *Server) FeedArticle(ctx context.Context, req *pb.FilterArticleRequest) (*pb.ArticlesResponse, error) {
@@ -75,23 +67,17 @@ func (server *Server) FeedArticle(ctx context.Context, req *pb.FilterArticleRequ
 
 	arg := port.ListArticleParams{
 		AuthArg: auth,
+		Sort:    req.GetSort(),
+		Cursor:  req.GetCursor(),
 		Offset:  offset,
 		Limit:   limit,
 	}
-	articles, err := server.service.Article().Feed(ctx, arg)
+	result, err := server.service.Article().Feed(ctx, arg)
 	if err != nil {
 		return nil, handleError(err)
 	}
 
-	res := &pb.ArticlesResponse{
-		Articles: []*pb.Article{},
-		Count:    int64(len(articles)),
-	}
-	for _, article := range articles {
-		res.Articles = append(res.Articles, serializeArticle(article))
-	}
-
-	return res, nil
+	return serializeArticles(result), nil
 }
 
 func (server *Server) GetArticle(ctx context.Context, req *pb.GetArticleRequest) (*pb.ArticleResponse, error) {
@@ -146,6 +132,7 @@ func (server *Server) UpdateArticle(ctx context.Context, req *pb.UpdateArticleRe
 			Title:       req.GetArticle().GetTitle(),
 			Description: req.GetArticle().GetDescription(),
 			Body:        req.GetArticle().GetBody(),
+			Version:     int(req.GetArticle().GetVersion()),
 		},
 	})
 	if err != nil {
@@ -181,11 +168,20 @@ func (server *Server) CreateComment(ctx context.Context, req *pb.CreateCommentRe
 		return nil, handleError(err)
 	}
 
+	var parentID domain.ID
+	if req.GetComment().ParentId != nil {
+		parentID, err = domain.ParseID(req.GetComment().GetParentId())
+		if err != nil {
+			return nil, handleError(err)
+		}
+	}
+
 	result, err := server.service.Article().AddComment(ctx, port.AddCommentParams{
 		AuthArg: auth,
 		Slug:    req.GetSlug(),
 		Comment: domain.Comment{
-			Body: req.GetComment().GetBody(),
+			ParentID: parentID,
+			Body:     req.GetComment().GetBody(),
 		},
 	})
 	if err != nil {
@@ -200,23 +196,57 @@ func (server *Server) CreateComment(ctx context.Context, req *pb.CreateCommentRe
 
 func (server *Server) ListComment(ctx context.Context, req *pb.ListCommentRequest) (*pb.CommentsResponse, error) {
 	auth, _ := server.authorizeUser(ctx)
-	comments, err := server.service.Article().ListComments(ctx, port.ListCommentParams{
+	result, err := server.service.Article().ListComments(ctx, port.ListCommentParams{
 		AuthArg: auth,
 		Slug:    req.GetSlug(),
+		Tree:    req.GetTree(),
+		Sort:    req.GetSort(),
+		Cursor:  req.GetCursor(),
+		Limit:   int(req.GetLimit()),
 	})
 	if err != nil {
 		return nil, handleError(err)
 	}
 
 	res := &pb.CommentsResponse{
-		Comments: []*pb.Comment{},
+		Comments:   []*pb.Comment{},
+		Count:      int64(result.Count),
+		NextCursor: result.NextCursor,
 	}
-	for _, comment := range comments {
+	for _, comment := range result.Comments {
 		res.Comments = append(res.Comments, serializeComment(comment))
 	}
 	return res, nil
 }
 
+func (server *Server) UpdateComment(ctx context.Context, req *pb.UpdateCommentRequest) (*pb.CommentResponse, error) {
+	commentID, err := domain.ParseID(req.GetCommentId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	auth, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	result, err := server.service.Article().UpdateComment(ctx, port.UpdateCommentParams{
+		AuthArg:   auth,
+		Slug:      req.GetSlug(),
+		CommentID: commentID,
+		Comment: domain.Comment{
+			Body: req.GetComment().GetBody(),
+		},
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	res := &pb.CommentResponse{
+		Comment: serializeComment(result),
+	}
+	return res, nil
+}
+
 func (server *Server) DeleteComment(ctx context.Context, req *pb.GetCommentRequest) (*pb.Response, error) {
 	commentID, err := domain.ParseID(req.GetCommentId())
 	if err != nil {
@@ -238,6 +268,30 @@ func (server *Server) DeleteComment(ctx context.Context, req *pb.GetCommentReque
 	return res, nil
 }
 
+func (server *Server) ListCommentRevision(ctx context.Context, req *pb.GetCommentRequest) (*pb.CommentRevisionsResponse, error) {
+	commentID, err := domain.ParseID(req.GetCommentId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	auth, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	revisions, err := server.service.Article().ListCommentRevisions(ctx, port.ListCommentRevisionParams{
+		AuthArg:   auth,
+		Slug:      req.GetSlug(),
+		CommentID: commentID,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	res := &pb.CommentRevisionsResponse{Revisions: []*pb.CommentRevision{}}
+	for _, revision := range revisions {
+		res.Revisions = append(res.Revisions, serializeCommentRevision(revision))
+	}
+	return res, nil
+}
+
 func (server *Server) FavoriteArticle(ctx context.Context, req *pb.GetArticleRequest) (*pb.ArticleResponse, error) {
 	auth, err := server.authorizeUser(ctx)
 	if err != nil {
@@ -286,3 +340,35 @@ func (server *Server) ListTag(ctx context.Context, _ *emptypb.Empty) (*pb.ListTa
 	}
 	return res, nil
 }
+
+func (server *Server) ListMention(ctx context.Context, req *pb.ListMentionRequest) (*pb.MentionsResponse, error) {
+	auth, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	offset := 0
+	if req.Offset != nil {
+		offset = int(req.GetOffset())
+	}
+
+	limit := DefaultPaginationSize
+	if req.Limit != nil {
+		limit = int(req.GetLimit())
+	}
+
+	mentions, err := server.service.Article().ListMentions(ctx, port.ListMentionParams{
+		AuthArg: auth,
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	res := &pb.MentionsResponse{Mentions: []*pb.Mention{}}
+	for _, mention := range mentions {
+		res.Mentions = append(res.Mentions, serializeMention(mention))
+	}
+	return res, nil
+}
@@ -1,7 +1,10 @@
 package api
 
 import (
+	"sort"
+
 	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -25,8 +28,32 @@ func handleError(err error) error {
 		code = codes.Unauthenticated
 	case exception.TypeValidation:
 		code = codes.InvalidArgument
+	case exception.TypePreconditionFailed:
+		code = codes.FailedPrecondition
+	case exception.TypeConflict:
+		code = codes.Aborted
+	case exception.TypeTooManyRequests:
+		code = codes.ResourceExhausted
+	case exception.TypeUnavailable:
+		code = codes.Unavailable
 	default:
 		code = codes.Internal
 	}
-	return status.Error(code, err.Error())
+	st := status.New(code, err.Error())
+	// errors map carried as field violation, so gateway render the same body as restful
+	violations := []*errdetails.BadRequest_FieldViolation{}
+	fields := make([]string, 0, len(fail.Errors))
+	for field := range fail.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		for _, msg := range fail.Errors[field] {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: msg})
+		}
+	}
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
 }
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	healthCheckInterval = 5 * time.Second
+	serviceName         = "pb.RealWorld"
+)
+
+// watchHealth update serving status from readiness check, empty service is whole server
+func (server *Server) watchHealth(ctx context.Context, healthServer *health.Server) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		status := grpc_health_v1.HealthCheckResponse_SERVING
+		if _, err := server.service.Health().Ready(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+			server.logger.Error().Err(err).Msgf("not ready: %s", exception.Into(err).Message)
+		}
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus(serviceName, status)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// liveness and readiness on admin port for http probes
+func (server *Server) liveness(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (server *Server) readiness(w http.ResponseWriter, r *http.Request) {
+	result, err := server.service.Health().Ready(r.Context())
+	res := map[string]any{"status": "ok", "database": result.Database}
+	if result.LatestMigration > 0 {
+		res["migration"] = map[string]any{
+			"version": result.MigrationVersion,
+			"latest":  result.LatestMigration,
+			"dirty":   result.MigrationDirty,
+		}
+	}
+	if err != nil {
+		res["status"] = "unavailable"
+		res["error"] = exception.Into(err).Message
+		writeHealth(w, http.StatusServiceUnavailable, res)
+		return
+	}
+	writeHealth(w, http.StatusOK, res)
+}
+
+func writeHealth(w http.ResponseWriter, code int, res any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(res)
+}
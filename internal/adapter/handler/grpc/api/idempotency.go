@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/pb"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	idempotencyKeyHeader      = "idempotency-key"
+	idempotencyReplayedHeader = "idempotent-replayed"
+)
+
+// idempotentMethods methods accept idempotency key, mapped to empty response for replay
+var idempotentMethods = map[string]func() proto.Message{
+	"/pb.RealWorld/CreateArticle": func() proto.Message { return &pb.ArticleResponse{} },
+	"/pb.RealWorld/CreateComment": func() proto.Message { return &pb.CommentResponse{} },
+}
+
+// Idempotency replay stored response of request retried with the same idempotency-key metadata,
+// only success response is stored, so failed request can be retried with the same key
+func (server *Server) Idempotency(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	newResponse, ok := idempotentMethods[info.FullMethod]
+	if !ok {
+		return handler(ctx, req)
+	}
+	metaData, _ := metadata.FromIncomingContext(ctx)
+	keys := metaData.Get(idempotencyKeyHeader)
+	if len(keys) == 0 || keys[0] == "" {
+		return handler(ctx, req)
+	}
+	key := keys[0]
+
+	authArg, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+	message, ok := req.(proto.Message)
+	if !ok {
+		return nil, handleError(exception.New(exception.TypeInternal, "request is not proto message", nil))
+	}
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return nil, handleError(exception.Into(err))
+	}
+
+	record, replay, err := server.service.Idempotency().Begin(ctx, port.BeginIdempotencyParams{
+		AuthArg:     authArg,
+		Key:         key,
+		RequestHash: domain.HashIdempotencyRequest([]byte(info.FullMethod), body),
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	if replay {
+		res := newResponse()
+		if err := proto.Unmarshal(record.Response, res); err != nil {
+			return nil, handleError(exception.Into(err))
+		}
+		grpc.SetHeader(ctx, metadata.Pairs(idempotencyReplayedHeader, "true"))
+		return res, nil
+	}
+
+	logger := port.GetCtxSubLogger(ctx, server.logger)
+	res, err := handler(ctx, req)
+	// client disconnect must not leave key reserved
+	ctx = context.WithoutCancel(ctx)
+	if err != nil {
+		if err := server.service.Idempotency().Release(ctx, record); err != nil {
+			logger.Error().Err(err).Field("idempotency_key", key).Msg("failed to release idempotency key")
+		}
+		return res, err
+	}
+
+	// keep key even when store failed, request must not run twice
+	resMessage, ok := res.(proto.Message)
+	if ok {
+		record.Response, err = proto.Marshal(resMessage)
+	}
+	if !ok || err != nil {
+		logger.Error().Err(err).Field("idempotency_key", key).Msg("failed to encode idempotent response")
+		return res, nil
+	}
+	if err := server.service.Idempotency().Complete(ctx, record); err != nil {
+		logger.Error().Err(err).Field("idempotency_key", key).Msg("failed to store idempotent response")
+	}
+	return res, nil
+}
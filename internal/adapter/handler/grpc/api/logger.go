@@ -7,6 +7,8 @@ import (
 
 	"github.com/labasubagia/realworld-backend/internal/core/domain"
 	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/metrics"
+	"github.com/labasubagia/realworld-backend/internal/core/util/tracing"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -26,7 +28,7 @@ func (server *Server) Logger(ctx context.Context, req any, info *grpc.UnaryServe
 	metaData, ok := metadata.FromIncomingContext(ctx)
 	if ok {
 		if reqIDs := metaData.Get(reqIDHeader); len(reqIDs) > 0 {
-			userAgent = reqIDs[0]
+			reqID = reqIDs[0]
 		}
 		if userAgents := metaData.Get(userAgentHeader); len(userAgents) > 0 {
 			userAgent = userAgents[0]
@@ -36,7 +38,8 @@ func (server *Server) Logger(ctx context.Context, req any, info *grpc.UnaryServe
 	peerInfo, _ := peer.FromContext(ctx)
 	clientIP := peerInfo.Addr.String()
 
-	logger := server.logger.NewInstance().Field("request_id", reqID).Logger()
+	ctx, span := startSpan(ctx, info.FullMethod, reqID)
+	logger := server.logger.NewInstance().Field("request_id", reqID).Field("trace_id", tracing.TraceID(ctx)).Logger()
 	ctx = context.WithValue(ctx, port.SubLoggerCtxKey, logger)
 
 	startTime := time.Now()
@@ -47,6 +50,8 @@ func (server *Server) Logger(ctx context.Context, req any, info *grpc.UnaryServe
 	if st, ok := status.FromError(err); ok {
 		code = st.Code()
 	}
+	endSpan(span, code)
+	metrics.ObserveRequest("grpc", info.FullMethod, code.String(), duration)
 
 	logEvent := logger.Info()
 	if code == codes.Internal {
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/pb"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (server *Server) ListNotification(ctx context.Context, req *pb.ListNotificationRequest) (*pb.NotificationsResponse, error) {
+	auth, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	offset := 0
+	if req.Offset != nil {
+		offset = int(req.GetOffset())
+	}
+
+	limit := DefaultPaginationSize
+	if req.Limit != nil {
+		limit = int(req.GetLimit())
+	}
+
+	result, err := server.service.Notification().List(ctx, port.ListNotificationParams{
+		AuthArg:    auth,
+		UnreadOnly: req.GetUnread(),
+		Offset:     offset,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	res := &pb.NotificationsResponse{
+		Notifications: []*pb.Notification{},
+		Count:         int64(result.Count),
+		UnreadCount:   int64(result.UnreadCount),
+	}
+	for _, notification := range result.Notifications {
+		res.Notifications = append(res.Notifications, serializeNotification(notification))
+	}
+	return res, nil
+}
+
+func (server *Server) MarkNotificationRead(ctx context.Context, req *pb.MarkNotificationReadRequest) (*pb.Response, error) {
+	auth, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	ids := []domain.ID{}
+	for _, value := range req.GetIds() {
+		id, err := domain.ParseID(value)
+		if err != nil {
+			return nil, handleError(err)
+		}
+		ids = append(ids, id)
+	}
+
+	err = server.service.Notification().MarkRead(ctx, port.MarkNotificationReadParams{
+		AuthArg: auth,
+		IDs:     ids,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	res := &pb.Response{Status: "OK"}
+	return res, nil
+}
+
+func (server *Server) MarkAllNotificationRead(ctx context.Context, _ *emptypb.Empty) (*pb.Response, error) {
+	auth, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	err = server.service.Notification().MarkAllRead(ctx, auth)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	res := &pb.Response{Status: "OK"}
+	return res, nil
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/labasubagia/realworld-backend/internal/core/util/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	retryAfterHeader   = "retry-after"
+	forwardedForHeader = "x-forwarded-for"
+)
+
+// methodClasses route class of auth and read methods, other methods limited as write
+var methodClasses = map[string]string{
+	"/pb.RealWorld/RegisterUser":        ratelimit.ClassAuth,
+	"/pb.RealWorld/LoginUser":           ratelimit.ClassAuth,
+	"/pb.RealWorld/CurrentUser":         ratelimit.ClassRead,
+	"/pb.RealWorld/GetProfile":          ratelimit.ClassRead,
+	"/pb.RealWorld/ListArticle":         ratelimit.ClassRead,
+	"/pb.RealWorld/FeedArticle":         ratelimit.ClassRead,
+	"/pb.RealWorld/GetArticle":          ratelimit.ClassRead,
+	"/pb.RealWorld/ListTag":             ratelimit.ClassRead,
+	"/pb.RealWorld/ListComment":         ratelimit.ClassRead,
+	"/pb.RealWorld/WatchFeed":           ratelimit.ClassRead,
+	"/pb.RealWorld/WatchComments":       ratelimit.ClassRead,
+	"/pb.RealWorld/ListMention":         ratelimit.ClassRead,
+	"/pb.RealWorld/ListNotification":    ratelimit.ClassRead,
+	"/pb.RealWorld/ListWebhook":         ratelimit.ClassRead,
+	"/pb.RealWorld/ListWebhookDelivery": ratelimit.ClassRead,
+	"/grpc.health.v1.Health/Check":      ratelimit.ClassRead,
+	"/grpc.health.v1.Health/Watch":      ratelimit.ClassRead,
+}
+
+func (server *Server) RateLimit(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := server.rateLimit(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (server *Server) StreamRateLimit(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := server.rateLimit(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// rateLimit take token of caller, identified by user id when token valid, otherwise peer ip
+func (server *Server) rateLimit(ctx context.Context, method string) error {
+	class, ok := methodClasses[method]
+	if !ok {
+		class = ratelimit.ClassWrite
+	}
+
+	key := "ip:" + clientIP(ctx)
+	if authArg, err := server.authorizeUser(ctx); err == nil && authArg.Payload != nil {
+		key = "user:" + authArg.Payload.UserID.String()
+	}
+
+	result, err := server.limiter.Allow(ctx, class, key)
+	if err != nil {
+		// store failure should not block traffic
+		port.GetCtxSubLogger(ctx, server.logger).Error().Err(err).Msg("failed to check rate limit")
+		return nil
+	}
+	if !result.Allowed {
+		retryAfter := strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds())))
+		grpc.SetHeader(ctx, metadata.Pairs(retryAfterHeader, retryAfter))
+		return handleError(exception.New(exception.TypeTooManyRequests, "too many requests", nil))
+	}
+	return nil
+}
+
+// clientIP of peer, request proxied by local gateway use the last forwarded address,
+// the one gateway appended, earlier addresses are client supplied
+func clientIP(ctx context.Context) string {
+	peerInfo, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(peerInfo.Addr.String())
+	if err != nil {
+		host = peerInfo.Addr.String()
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return host
+	}
+	metaData, _ := metadata.FromIncomingContext(ctx)
+	if values := metaData.Get(forwardedForHeader); len(values) > 0 {
+		addresses := strings.Split(values[len(values)-1], ",")
+		if forwarded := strings.TrimSpace(addresses[len(addresses)-1]); forwarded != "" {
+			return forwarded
+		}
+	}
+	return host
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/pb"
 	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -13,6 +14,7 @@ func serializeUser(arg domain.User) *pb.User {
 		Bio:      arg.Bio,
 		Image:    arg.Image,
 		Token:    arg.Token,
+		Version:  int64(arg.Version),
 	}
 }
 
@@ -41,15 +43,92 @@ func serializeArticle(arg domain.Article) *pb.Article {
 		Author:        serializeProfile(arg.Author),
 		CreatedAt:     timestamppb.New(arg.CreatedAt),
 		UpdatedAt:     timestamppb.New(arg.UpdatedAt),
+		Version:       int64(arg.Version),
 	}
 }
 
+func serializeArticles(arg port.ListArticleResult) *pb.ArticlesResponse {
+	res := &pb.ArticlesResponse{
+		Articles:   []*pb.Article{},
+		Count:      int64(arg.Count),
+		NextCursor: arg.NextCursor,
+	}
+	for _, article := range arg.Articles {
+		res.Articles = append(res.Articles, serializeArticle(article))
+	}
+	return res
+}
+
 func serializeComment(arg domain.Comment) *pb.Comment {
-	return &pb.Comment{
+	comment := &pb.Comment{
 		Id:        arg.ID.String(),
+		ParentId:  arg.ParentID.String(),
 		Body:      arg.Body,
+		Deleted:   arg.IsDeleted,
+		Edited:    arg.IsEdited,
 		Author:    serializeProfile(arg.Author),
 		CreatedAt: timestamppb.New(arg.CreatedAt),
 		UpdatedAt: timestamppb.New(arg.UpdatedAt),
 	}
+	for _, reply := range arg.Replies {
+		comment.Replies = append(comment.Replies, serializeComment(reply))
+	}
+	return comment
+}
+
+func serializeCommentRevision(arg domain.CommentRevision) *pb.CommentRevision {
+	return &pb.CommentRevision{
+		Id:        arg.ID.String(),
+		Body:      arg.Body,
+		CreatedAt: timestamppb.New(arg.CreatedAt),
+	}
+}
+
+func serializeMention(arg domain.Mention) *pb.Mention {
+	return &pb.Mention{
+		Id:           arg.ID.String(),
+		ArticleSlug:  arg.Article.Slug,
+		ArticleTitle: arg.Article.Title,
+		CommentId:    arg.CommentID.String(),
+		Author:       serializeProfile(arg.Author),
+		CreatedAt:    timestamppb.New(arg.CreatedAt),
+	}
+}
+
+func serializeNotification(arg domain.Notification) *pb.Notification {
+	return &pb.Notification{
+		Id:           arg.ID.String(),
+		Type:         arg.Type,
+		Read:         arg.IsRead(),
+		Actor:        serializeProfile(arg.Actor),
+		ArticleSlug:  arg.Article.Slug,
+		ArticleTitle: arg.Article.Title,
+		CommentId:    arg.CommentID.String(),
+		CreatedAt:    timestamppb.New(arg.CreatedAt),
+	}
+}
+
+func serializeWebhook(arg domain.Webhook) *pb.Webhook {
+	return &pb.Webhook{
+		Id:        arg.ID.String(),
+		Url:       arg.URL,
+		Secret:    arg.Secret,
+		Events:    arg.Events,
+		CreatedAt: timestamppb.New(arg.CreatedAt),
+		UpdatedAt: timestamppb.New(arg.UpdatedAt),
+	}
+}
+
+func serializeWebhookDelivery(arg domain.WebhookDelivery) *pb.WebhookDelivery {
+	return &pb.WebhookDelivery{
+		Id:           arg.ID.String(),
+		Event:        arg.Event,
+		Payload:      arg.Payload,
+		Status:       arg.Status,
+		Attempts:     int64(arg.Attempts),
+		ResponseCode: int64(arg.ResponseCode),
+		Error:        arg.Error,
+		CreatedAt:    timestamppb.New(arg.CreatedAt),
+		UpdatedAt:    timestamppb.New(arg.UpdatedAt),
+	}
 }
@@ -1,23 +1,35 @@
 package api
 
 import (
+	"context"
+	"expvar"
 	"fmt"
 	"net"
+	"net/http"
+	"time"
 
 	"github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/pb"
 	"github.com/labasubagia/realworld-backend/internal/core/port"
 	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/metrics"
+	"github.com/labasubagia/realworld-backend/internal/core/util/ratelimit"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
-const TypeGrpc = "grpc"
+const (
+	TypeGrpc = "grpc"
+)
 
 type Server struct {
 	pb.UnimplementedRealWorldServer
 	config  util.Config
 	service port.Service
 	logger  port.Logger
+	limiter *ratelimit.Limiter
+	done    chan struct{} // closed on shutdown, end open streams
 }
 
 func NewServer(config util.Config, repository port.Service, logger port.Logger) port.Server {
@@ -25,23 +37,88 @@ func NewServer(config util.Config, repository port.Service, logger port.Logger)
 		config:  config,
 		service: repository,
 		logger:  logger,
+		limiter: ratelimit.New(config),
+		done:    make(chan struct{}),
 	}
 	return server
 }
 
-func (server *Server) Start() error {
-	logger := grpc.UnaryInterceptor(server.Logger)
+func (server *Server) Start(ctx context.Context) error {
+	logger := grpc.ChainUnaryInterceptor(server.Logger, server.RateLimit, server.Idempotency)
+	streamInterceptor := grpc.ChainStreamInterceptor(server.StreamLogger, server.StreamRateLimit, server.StreamAuth)
 
-	grpcServer := grpc.NewServer(logger)
+	grpcServer := grpc.NewServer(logger, streamInterceptor)
 	pb.RegisterRealWorldServer(grpcServer, server)
 	reflection.Register(grpcServer)
 
+	// standard health service, status follow readiness check
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	go server.watchHealth(ctx, healthServer)
+
+	// grpc server has no http route, metrics served on admin port
+	var admin *http.Server
+	if server.config.AdminPort > 0 {
+		admin = server.newAdmin()
+		go func() {
+			server.logger.Info().Msgf("admin server listen to port %d", server.config.AdminPort)
+			if err := admin.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				server.logger.Error().Err(err).Msg("failed to serve admin")
+			}
+		}()
+	}
+
 	listen, err := net.Listen("tcp", fmt.Sprintf(":%d", server.config.ServerPort))
 	if err != nil {
 		return err
 	}
-	if err := grpcServer.Serve(listen); err != nil {
+	serveErr := make(chan error, 1)
+	go func() {
+		server.logger.Info().Msgf("grpc server listen to port %d", server.config.ServerPort)
+		serveErr <- grpcServer.Serve(listen)
+	}()
+
+	select {
+	case err := <-serveErr:
 		return err
+	case <-ctx.Done():
+	}
+	server.logger.Info().Msg("shutdown grpc server...")
+
+	// graceful stop wait active rpc, streams never end by itself
+	healthServer.Shutdown()
+	close(server.done)
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(server.config.DrainTimeout()):
+		server.logger.Info().Msg("drain timeout, force stop grpc server")
+		grpcServer.Stop()
+	}
+
+	if admin != nil {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), server.config.DrainTimeout())
+		defer cancel()
+		if err := admin.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
 	}
+	server.logger.Info().Msg("grpc server exiting")
 	return nil
 }
+
+func (server *Server) newAdmin() *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.HandleFunc("/healthz", server.liveness)
+	mux.HandleFunc("/readyz", server.readiness)
+	return &http.Server{
+		Addr:    fmt.Sprintf(":%d", server.config.AdminPort),
+		Handler: mux,
+	}
+}
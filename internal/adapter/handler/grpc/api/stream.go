@@ -0,0 +1,177 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/pb"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/metrics"
+	"github.com/labasubagia/realworld-backend/internal/core/util/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// serverStream replace stream context so interceptor can pass values to handler
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (server *Server) StreamLogger(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := ss.Context()
+
+	reqID := domain.NewID().String()
+	var userAgent string
+	metaData, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		if reqIDs := metaData.Get(reqIDHeader); len(reqIDs) > 0 {
+			reqID = reqIDs[0]
+		}
+		if userAgents := metaData.Get(userAgentHeader); len(userAgents) > 0 {
+			userAgent = userAgents[0]
+		}
+	}
+
+	peerInfo, _ := peer.FromContext(ctx)
+	clientIP := peerInfo.Addr.String()
+
+	ctx, span := startSpan(ctx, info.FullMethod, reqID)
+	logger := server.logger.NewInstance().Field("request_id", reqID).Field("trace_id", tracing.TraceID(ctx)).Logger()
+	ctx = context.WithValue(ctx, port.SubLoggerCtxKey, logger)
+
+	startTime := time.Now()
+	err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	duration := time.Since(startTime)
+
+	code := codes.Unknown
+	if st, ok := status.FromError(err); ok {
+		code = st.Code()
+	}
+	endSpan(span, code)
+	metrics.ObserveRequest("grpc", info.FullMethod, code.String(), duration)
+
+	logEvent := logger.Info()
+	if code == codes.Internal {
+		logEvent = logger.Error()
+	}
+
+	logEvent.
+		Field("protocol", "grpc").
+		Field("method", info.FullMethod).
+		Field("client_ip", clientIP).
+		Field("user_agent", userAgent).
+		Field("status_code", int(code)).
+		Field("status_text", code.String()).
+		Field("duration", duration).
+		Msg("receive grpc stream")
+
+	return err
+}
+
+// StreamAuth authorize stream once on open, invalid token is rejected
+// while missing token continue as anonymous
+func (server *Server) StreamAuth(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx := ss.Context()
+	authArg, err := server.authorizeUser(ctx)
+	if err != nil && hasToken(ctx) {
+		return handleError(err)
+	}
+	ctx = context.WithValue(ctx, authorizationArgKey, authArg)
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+func hasToken(ctx context.Context) bool {
+	metaData, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(metaData.Get(authorizationHeader)) > 0
+}
+
+func getStreamAuthArg(ctx context.Context) port.AuthParams {
+	authArg, _ := ctx.Value(authorizationArgKey).(port.AuthParams)
+	return authArg
+}
+
+func (server *Server) WatchFeed(req *pb.WatchFeedRequest, stream pb.RealWorld_WatchFeedServer) error {
+	ctx := stream.Context()
+	sub, err := server.service.Event().Subscribe(ctx, port.SubscribeEventParams{
+		AuthArg:     getStreamAuthArg(ctx),
+		LastEventID: req.GetLastEventId(),
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-server.done:
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				return errTooSlow
+			}
+			article, ok := event.Data.(domain.Article)
+			if event.Type != domain.EventTypeArticle || !ok {
+				continue
+			}
+			err := stream.Send(&pb.ArticleEvent{
+				Id:      event.ID,
+				Type:    event.Type,
+				Article: serializeArticle(article),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (server *Server) WatchComments(req *pb.WatchCommentsRequest, stream pb.RealWorld_WatchCommentsServer) error {
+	ctx := stream.Context()
+	sub, err := server.service.Event().SubscribeComments(ctx, port.SubscribeCommentParams{
+		Slug:        req.GetSlug(),
+		LastEventID: req.GetLastEventId(),
+	})
+	if err != nil {
+		return handleError(err)
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-server.done:
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				return errTooSlow
+			}
+			comment, ok := event.Data.(domain.Comment)
+			if !ok {
+				continue
+			}
+			err := stream.Send(&pb.CommentEvent{
+				Id:      event.ID,
+				Type:    event.Type,
+				Comment: serializeComment(comment),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// client can reopen stream with last received event id
+var errTooSlow = status.Error(codes.ResourceExhausted, "stream closed, client too slow")
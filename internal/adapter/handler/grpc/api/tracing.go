@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/core/util/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// metadataCarrier read and write trace context such as traceparent in grpc metadata
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	values := metadata.MD(m).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (m metadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// startSpan continue trace of incoming metadata
+func startSpan(ctx context.Context, method, reqID string) (context.Context, trace.Span) {
+	if metaData, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = tracing.Extract(ctx, metadataCarrier(metaData))
+	}
+	return tracing.Start(ctx, method,
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.method", method),
+		attribute.String("request_id", reqID),
+	)
+}
+
+func endSpan(span trace.Span, code codes.Code) {
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.DeadlineExceeded:
+		tracing.Fail(span, code.String())
+	}
+	span.End()
+}
@@ -71,6 +71,7 @@ func (server *Server) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 			Password: req.GetUser().GetPassword(),
 			Image:    req.GetUser().GetImage(),
 			Bio:      req.GetUser().GetBio(),
+			Version:  int(req.GetUser().GetVersion()),
 		},
 	})
 	if err != nil {
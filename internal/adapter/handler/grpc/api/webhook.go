@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/pb"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (server *Server) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.WebhookResponse, error) {
+	auth, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	webhook, err := server.service.Webhook().Create(ctx, port.CreateWebhookParams{
+		AuthArg: auth,
+		Webhook: domain.Webhook{
+			URL:    req.GetWebhook().GetUrl(),
+			Events: req.GetWebhook().GetEvents(),
+		},
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	res := &pb.WebhookResponse{
+		Webhook: serializeWebhook(webhook),
+	}
+	return res, nil
+}
+
+func (server *Server) ListWebhook(ctx context.Context, _ *emptypb.Empty) (*pb.WebhooksResponse, error) {
+	auth, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	webhooks, err := server.service.Webhook().List(ctx, auth)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	res := &pb.WebhooksResponse{Webhooks: []*pb.Webhook{}}
+	for _, webhook := range webhooks {
+		webhook.Secret = "" // secret only shown on create
+		res.Webhooks = append(res.Webhooks, serializeWebhook(webhook))
+	}
+	return res, nil
+}
+
+func (server *Server) DeleteWebhook(ctx context.Context, req *pb.GetWebhookRequest) (*pb.Response, error) {
+	webhookID, err := domain.ParseID(req.GetWebhookId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	auth, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	err = server.service.Webhook().Delete(ctx, port.DeleteWebhookParams{
+		AuthArg:   auth,
+		WebhookID: webhookID,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+	res := &pb.Response{Status: "OK"}
+	return res, nil
+}
+
+func (server *Server) ListWebhookDelivery(ctx context.Context, req *pb.ListWebhookDeliveryRequest) (*pb.WebhookDeliveriesResponse, error) {
+	webhookID, err := domain.ParseID(req.GetWebhookId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	auth, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	offset := 0
+	if req.Offset != nil {
+		offset = int(req.GetOffset())
+	}
+
+	limit := DefaultPaginationSize
+	if req.Limit != nil {
+		limit = int(req.GetLimit())
+	}
+
+	deliveries, err := server.service.Webhook().ListDeliveries(ctx, port.ListWebhookDeliveryParams{
+		AuthArg:   auth,
+		WebhookID: webhookID,
+		Offset:    offset,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	res := &pb.WebhookDeliveriesResponse{Deliveries: []*pb.WebhookDelivery{}}
+	for _, delivery := range deliveries {
+		res.Deliveries = append(res.Deliveries, serializeWebhookDelivery(delivery))
+	}
+	return res, nil
+}
+
+func (server *Server) RedeliverWebhook(ctx context.Context, req *pb.RedeliverWebhookRequest) (*pb.WebhookDeliveryResponse, error) {
+	webhookID, err := domain.ParseID(req.GetWebhookId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	deliveryID, err := domain.ParseID(req.GetDeliveryId())
+	if err != nil {
+		return nil, handleError(err)
+	}
+	auth, err := server.authorizeUser(ctx)
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	delivery, err := server.service.Webhook().Redeliver(ctx, port.RedeliverWebhookParams{
+		AuthArg:    auth,
+		WebhookID:  webhookID,
+		DeliveryID: deliveryID,
+	})
+	if err != nil {
+		return nil, handleError(err)
+	}
+
+	res := &pb.WebhookDeliveryResponse{
+		Delivery: serializeWebhookDelivery(delivery),
+	}
+	return res, nil
+}
@@ -0,0 +1,73 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/textproto"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorHandler render grpc status as restful error body,
+// errors map is read from field violation attached by grpc server
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	fail := exception.New(exception.TypeInternal, st.Message(), nil)
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, violation := range badRequest.GetFieldViolations() {
+			fail.AddError(violation.GetField(), violation.GetDescription())
+		}
+	}
+	if !fail.HasError() {
+		fail.AddError("exception", fail.Message)
+	}
+
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for key, values := range md.HeaderMD {
+			if !responseHeaders[key] {
+				continue
+			}
+			for _, value := range values {
+				w.Header().Add(textproto.CanonicalMIMEHeaderKey(key), value)
+			}
+		}
+	}
+
+	var statusCode int
+	switch st.Code() {
+	case codes.InvalidArgument:
+		statusCode = http.StatusUnprocessableEntity
+	case codes.FailedPrecondition:
+		statusCode = http.StatusPreconditionFailed
+	default:
+		statusCode = runtime.HTTPStatusFromCode(st.Code())
+	}
+	writeJSON(w, statusCode, fail)
+}
+
+// routingErrorHandler respond unknown route like restful server
+func routingErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+	switch httpStatus {
+	case http.StatusNotFound:
+		writeJSON(w, httpStatus, map[string]string{"message": "page not found"})
+	case http.StatusMethodNotAllowed:
+		writeJSON(w, httpStatus, map[string]string{"message": "no method provided"})
+	default:
+		runtime.DefaultRoutingErrorHandler(ctx, mux, marshaler, w, r, httpStatus)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,87 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// marshaler render message like restful serializer,
+// zero value is kept and int64 is number instead of protojson string
+type marshaler struct {
+	runtime.JSONPb
+}
+
+func newMarshaler() *marshaler {
+	return &marshaler{
+		JSONPb: runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{EmitUnpopulated: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		},
+	}
+}
+
+func (m *marshaler) Marshal(v any) ([]byte, error) {
+	message, ok := v.(proto.Message)
+	if !ok {
+		return m.JSONPb.Marshal(v)
+	}
+	body, err := m.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(int64Number(message.ProtoReflect().Descriptor(), value))
+}
+
+// int64Number walk decoded json of message, turn int64 field back to number
+func int64Number(desc protoreflect.MessageDescriptor, value any) any {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		item, ok := object[field.JSONName()]
+		if !ok || field.IsMap() {
+			continue
+		}
+		if !field.IsList() {
+			object[field.JSONName()] = int64Field(field, item)
+			continue
+		}
+		if items, ok := item.([]any); ok {
+			for j := range items {
+				items[j] = int64Field(field, items[j])
+			}
+		}
+	}
+	return object
+}
+
+func int64Field(field protoreflect.FieldDescriptor, value any) any {
+	switch field.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if text, ok := value.(string); ok {
+			return json.Number(text)
+		}
+	case protoreflect.MessageKind:
+		// well known type such as timestamp has its own json form
+		if field.Message().ParentFile().Package() != "google.protobuf" {
+			return int64Number(field.Message(), value)
+		}
+	}
+	return value
+}
@@ -0,0 +1,135 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/pb"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+const TypeGateway = "gateway"
+
+// forwardHeaders request header passed as grpc metadata, authorization is always passed
+var forwardHeaders = map[string]bool{
+	"Idempotency-Key": true,
+	"Request-Id":      true,
+	"Traceparent":     true,
+	"Tracestate":      true,
+}
+
+// responseHeaders grpc header written as is, same name as restful server
+var responseHeaders = map[string]bool{
+	"retry-after":         true,
+	"idempotent-replayed": true,
+}
+
+// createdMethods respond 201 like restful server
+var createdMethods = map[string]bool{
+	"/pb.RealWorld/RegisterUser":  true,
+	"/pb.RealWorld/CreateArticle": true,
+}
+
+// Server translate restful json request into call to grpc server on grpc port,
+// routes are derived from http annotation of service.proto
+type Server struct {
+	config util.Config
+	logger port.Logger
+}
+
+func NewServer(config util.Config, logger port.Logger) port.Server {
+	return &Server{
+		config: config,
+		logger: logger,
+	}
+}
+
+func (server *Server) Start(ctx context.Context) error {
+	// dial is not blocking, grpc server may start after gateway
+	conn, err := grpc.Dial(
+		fmt.Sprintf("localhost:%d", server.config.GrpcPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	mux, err := newMux(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", server.config.ServerPort),
+		Handler: mux,
+	}
+
+	listenErr := make(chan error, 1)
+	go func() {
+		server.logger.Info().Msgf("gateway server listen to port %d, proxy grpc port %d", server.config.ServerPort, server.config.GrpcPort)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			listenErr <- err
+		}
+	}()
+
+	select {
+	case err := <-listenErr:
+		return err
+	case <-ctx.Done():
+	}
+	server.logger.Info().Msg("shutdown gateway server...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), server.config.DrainTimeout())
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	server.logger.Info().Msg("gateway server exiting")
+	return nil
+}
+
+// newMux route restful request to grpc connection
+func newMux(ctx context.Context, conn *grpc.ClientConn) (*runtime.ServeMux, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, newMarshaler()),
+		runtime.WithIncomingHeaderMatcher(incomingHeader),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeader),
+		runtime.WithForwardResponseOption(responseStatus),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithRoutingErrorHandler(routingErrorHandler),
+	)
+	if err := pb.RegisterRealWorldHandler(ctx, mux, conn); err != nil {
+		return nil, err
+	}
+	return mux, nil
+}
+
+func incomingHeader(key string) (string, bool) {
+	if forwardHeaders[textproto.CanonicalMIMEHeaderKey(key)] {
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func outgoingHeader(key string) (string, bool) {
+	if responseHeaders[key] {
+		return textproto.CanonicalMIMEHeaderKey(key), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+func responseStatus(ctx context.Context, w http.ResponseWriter, _ proto.Message) error {
+	if method, ok := runtime.RPCMethod(ctx); ok && createdMethods[method] {
+		w.WriteHeader(http.StatusCreated)
+	}
+	return nil
+}
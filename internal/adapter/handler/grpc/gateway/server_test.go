@@ -0,0 +1,182 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/pb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// stubServer answer from request only, so response of gateway is predictable
+type stubServer struct {
+	pb.UnimplementedRealWorldServer
+}
+
+func (stubServer) GetArticle(_ context.Context, req *pb.GetArticleRequest) (*pb.ArticleResponse, error) {
+	return &pb.ArticleResponse{Article: &pb.Article{
+		Slug:          req.GetSlug(),
+		FavoriteCount: 9007199254740993, // above 2^53, lose precision as float
+		Version:       3,
+	}}, nil
+}
+
+func (stubServer) FeedArticle(context.Context, *pb.FilterArticleRequest) (*pb.ArticlesResponse, error) {
+	return &pb.ArticlesResponse{
+		Articles: []*pb.Article{{Slug: "from-feed"}},
+		Count:    1,
+	}, nil
+}
+
+func (stubServer) CreateArticle(_ context.Context, req *pb.CreateArticleRequest) (*pb.ArticleResponse, error) {
+	return &pb.ArticleResponse{Article: &pb.Article{
+		Slug:    strings.ToLower(req.GetArticle().GetTitle()),
+		TagList: req.GetArticle().GetTagList(),
+		Version: 1,
+	}}, nil
+}
+
+func (stubServer) UpdateArticle(context.Context, *pb.UpdateArticleRequest) (*pb.ArticleResponse, error) {
+	return nil, status.Error(codes.FailedPrecondition, "article has been modified")
+}
+
+func (stubServer) RegisterUser(context.Context, *pb.RegisterUserRequest) (*pb.UserResponse, error) {
+	st, err := status.New(codes.InvalidArgument, "validation").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "email", Description: "invalid"},
+			{Field: "email", Description: "required"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nil, st.Err()
+}
+
+func newTestGateway(t *testing.T) http.Handler {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	pb.RegisterRealWorldServer(grpcServer, stubServer{})
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.DialContext(
+		ctx,
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	mux, err := newMux(ctx, conn)
+	require.Nil(t, err)
+	return mux
+}
+
+func serve(t *testing.T, handler http.Handler, method, path, body string) (*httptest.ResponseRecorder, map[string]any) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	result := map[string]any{}
+	decoder := json.NewDecoder(rec.Body)
+	decoder.UseNumber()
+	require.Nil(t, decoder.Decode(&result), rec.Body.String())
+	return rec, result
+}
+
+func TestGatewayInt64AsNumber(t *testing.T) {
+	rec, body := serve(t, newTestGateway(t), http.MethodGet, "/articles/hello", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	article, ok := body["article"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, json.Number("9007199254740993"), article["favoritesCount"])
+	require.Equal(t, json.Number("3"), article["version"])
+}
+
+func TestGatewayJSONName(t *testing.T) {
+	rec, body := serve(t, newTestGateway(t), http.MethodGet, "/articles/hello", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	article, ok := body["article"].(map[string]any)
+	require.True(t, ok)
+	require.Contains(t, article, "favoritesCount")
+	require.NotContains(t, article, "favoriteCount")
+	// zero value is kept like restful serializer
+	require.Equal(t, false, article["favorited"])
+	require.Equal(t, "", article["title"])
+
+	rec, body = serve(t, newTestGateway(t), http.MethodGet, "/articles/feed", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, json.Number("1"), body["articlesCount"])
+	require.NotContains(t, body, "count")
+}
+
+func TestGatewayStatus(t *testing.T) {
+	gateway := newTestGateway(t)
+
+	rec, body := serve(t, gateway, http.MethodPost, "/articles", `{"article":{"title":"Hello","tagList":["go"]}}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	article, ok := body["article"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "hello", article["slug"])
+	require.Equal(t, []any{"go"}, article["tagList"])
+
+	rec, body = serve(t, gateway, http.MethodPost, "/users", `{"user":{"email":"x"}}`)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	require.Equal(t, map[string]any{"errors": map[string]any{"email": []any{"invalid", "required"}}}, body)
+
+	rec, body = serve(t, gateway, http.MethodPut, "/articles/hello", `{"article":{"body":"new","version":1}}`)
+	require.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	require.Equal(t, map[string]any{"errors": map[string]any{"exception": []any{"article has been modified"}}}, body)
+
+	// status of method other than create is untouched
+	rec, _ = serve(t, gateway, http.MethodGet, "/articles/hello", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGatewayRoutePrecedence(t *testing.T) {
+	gateway := newTestGateway(t)
+
+	rec, body := serve(t, gateway, http.MethodGet, "/articles/feed", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, body, "articles")
+	require.NotContains(t, body, "article")
+
+	rec, body = serve(t, gateway, http.MethodGet, "/articles/feeds", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	article, ok := body["article"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "feeds", article["slug"])
+}
+
+func TestGatewayUnknownRoute(t *testing.T) {
+	gateway := newTestGateway(t)
+
+	rec, body := serve(t, gateway, http.MethodGet, "/unknown", "")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, map[string]any{"message": "page not found"}, body)
+
+	rec, body = serve(t, gateway, http.MethodPatch, "/articles/hello", "")
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	require.Equal(t, map[string]any{"message": "no method provided"}, body)
+}
@@ -31,8 +31,9 @@ type RealWorldClient interface {
 	FollowUser(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
 	UnFollowUser(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*ProfileResponse, error)
 	ListArticle(ctx context.Context, in *FilterArticleRequest, opts ...grpc.CallOption) (*ArticlesResponse, error)
-	FeedArticle(ctx context.Context, in *FilterArticleRequest, opts ...grpc.CallOption) (*ArticlesResponse, error)
 	GetArticle(ctx context.Context, in *GetArticleRequest, opts ...grpc.CallOption) (*ArticleResponse, error)
+	// after GetArticle, gateway match later route first so /articles/feed is not a slug
+	FeedArticle(ctx context.Context, in *FilterArticleRequest, opts ...grpc.CallOption) (*ArticlesResponse, error)
 	CreateArticle(ctx context.Context, in *CreateArticleRequest, opts ...grpc.CallOption) (*ArticleResponse, error)
 	UpdateArticle(ctx context.Context, in *UpdateArticleRequest, opts ...grpc.CallOption) (*ArticleResponse, error)
 	DeleteArticle(ctx context.Context, in *GetArticleRequest, opts ...grpc.CallOption) (*Response, error)
@@ -41,7 +42,20 @@ type RealWorldClient interface {
 	ListTag(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTagResponse, error)
 	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error)
 	ListComment(ctx context.Context, in *ListCommentRequest, opts ...grpc.CallOption) (*CommentsResponse, error)
+	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error)
 	DeleteComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*Response, error)
+	ListCommentRevision(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*CommentRevisionsResponse, error)
+	WatchFeed(ctx context.Context, in *WatchFeedRequest, opts ...grpc.CallOption) (RealWorld_WatchFeedClient, error)
+	WatchComments(ctx context.Context, in *WatchCommentsRequest, opts ...grpc.CallOption) (RealWorld_WatchCommentsClient, error)
+	ListMention(ctx context.Context, in *ListMentionRequest, opts ...grpc.CallOption) (*MentionsResponse, error)
+	ListNotification(ctx context.Context, in *ListNotificationRequest, opts ...grpc.CallOption) (*NotificationsResponse, error)
+	MarkNotificationRead(ctx context.Context, in *MarkNotificationReadRequest, opts ...grpc.CallOption) (*Response, error)
+	MarkAllNotificationRead(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Response, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error)
+	ListWebhook(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*Response, error)
+	ListWebhookDelivery(ctx context.Context, in *ListWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDeliveriesResponse, error)
+	RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*WebhookDeliveryResponse, error)
 }
 
 type realWorldClient struct {
@@ -124,18 +138,18 @@ func (c *realWorldClient) ListArticle(ctx context.Context, in *FilterArticleRequ
 	return out, nil
 }
 
-func (c *realWorldClient) FeedArticle(ctx context.Context, in *FilterArticleRequest, opts ...grpc.CallOption) (*ArticlesResponse, error) {
-	out := new(ArticlesResponse)
-	err := c.cc.Invoke(ctx, "/pb.RealWorld/FeedArticle", in, out, opts...)
+func (c *realWorldClient) GetArticle(ctx context.Context, in *GetArticleRequest, opts ...grpc.CallOption) (*ArticleResponse, error) {
+	out := new(ArticleResponse)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/GetArticle", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *realWorldClient) GetArticle(ctx context.Context, in *GetArticleRequest, opts ...grpc.CallOption) (*ArticleResponse, error) {
-	out := new(ArticleResponse)
-	err := c.cc.Invoke(ctx, "/pb.RealWorld/GetArticle", in, out, opts...)
+func (c *realWorldClient) FeedArticle(ctx context.Context, in *FilterArticleRequest, opts ...grpc.CallOption) (*ArticlesResponse, error) {
+	out := new(ArticlesResponse)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/FeedArticle", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -214,6 +228,15 @@ func (c *realWorldClient) ListComment(ctx context.Context, in *ListCommentReques
 	return out, nil
 }
 
+func (c *realWorldClient) UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error) {
+	out := new(CommentResponse)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/UpdateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *realWorldClient) DeleteComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := c.cc.Invoke(ctx, "/pb.RealWorld/DeleteComment", in, out, opts...)
@@ -223,6 +246,160 @@ func (c *realWorldClient) DeleteComment(ctx context.Context, in *GetCommentReque
 	return out, nil
 }
 
+func (c *realWorldClient) ListCommentRevision(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*CommentRevisionsResponse, error) {
+	out := new(CommentRevisionsResponse)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/ListCommentRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *realWorldClient) WatchFeed(ctx context.Context, in *WatchFeedRequest, opts ...grpc.CallOption) (RealWorld_WatchFeedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RealWorld_ServiceDesc.Streams[0], "/pb.RealWorld/WatchFeed", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &realWorldWatchFeedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RealWorld_WatchFeedClient interface {
+	Recv() (*ArticleEvent, error)
+	grpc.ClientStream
+}
+
+type realWorldWatchFeedClient struct {
+	grpc.ClientStream
+}
+
+func (x *realWorldWatchFeedClient) Recv() (*ArticleEvent, error) {
+	m := new(ArticleEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *realWorldClient) WatchComments(ctx context.Context, in *WatchCommentsRequest, opts ...grpc.CallOption) (RealWorld_WatchCommentsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RealWorld_ServiceDesc.Streams[1], "/pb.RealWorld/WatchComments", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &realWorldWatchCommentsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RealWorld_WatchCommentsClient interface {
+	Recv() (*CommentEvent, error)
+	grpc.ClientStream
+}
+
+type realWorldWatchCommentsClient struct {
+	grpc.ClientStream
+}
+
+func (x *realWorldWatchCommentsClient) Recv() (*CommentEvent, error) {
+	m := new(CommentEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *realWorldClient) ListMention(ctx context.Context, in *ListMentionRequest, opts ...grpc.CallOption) (*MentionsResponse, error) {
+	out := new(MentionsResponse)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/ListMention", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *realWorldClient) ListNotification(ctx context.Context, in *ListNotificationRequest, opts ...grpc.CallOption) (*NotificationsResponse, error) {
+	out := new(NotificationsResponse)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/ListNotification", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *realWorldClient) MarkNotificationRead(ctx context.Context, in *MarkNotificationReadRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/MarkNotificationRead", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *realWorldClient) MarkAllNotificationRead(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/MarkAllNotificationRead", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *realWorldClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error) {
+	out := new(WebhookResponse)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/CreateWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *realWorldClient) ListWebhook(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*WebhooksResponse, error) {
+	out := new(WebhooksResponse)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/ListWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *realWorldClient) DeleteWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *realWorldClient) ListWebhookDelivery(ctx context.Context, in *ListWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDeliveriesResponse, error) {
+	out := new(WebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/ListWebhookDelivery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *realWorldClient) RedeliverWebhook(ctx context.Context, in *RedeliverWebhookRequest, opts ...grpc.CallOption) (*WebhookDeliveryResponse, error) {
+	out := new(WebhookDeliveryResponse)
+	err := c.cc.Invoke(ctx, "/pb.RealWorld/RedeliverWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RealWorldServer is the server API for RealWorld service.
 // All implementations must embed UnimplementedRealWorldServer
 // for forward compatibility
@@ -235,8 +412,9 @@ type RealWorldServer interface {
 	FollowUser(context.Context, *GetProfileRequest) (*ProfileResponse, error)
 	UnFollowUser(context.Context, *GetProfileRequest) (*ProfileResponse, error)
 	ListArticle(context.Context, *FilterArticleRequest) (*ArticlesResponse, error)
-	FeedArticle(context.Context, *FilterArticleRequest) (*ArticlesResponse, error)
 	GetArticle(context.Context, *GetArticleRequest) (*ArticleResponse, error)
+	// after GetArticle, gateway match later route first so /articles/feed is not a slug
+	FeedArticle(context.Context, *FilterArticleRequest) (*ArticlesResponse, error)
 	CreateArticle(context.Context, *CreateArticleRequest) (*ArticleResponse, error)
 	UpdateArticle(context.Context, *UpdateArticleRequest) (*ArticleResponse, error)
 	DeleteArticle(context.Context, *GetArticleRequest) (*Response, error)
@@ -245,7 +423,20 @@ type RealWorldServer interface {
 	ListTag(context.Context, *emptypb.Empty) (*ListTagResponse, error)
 	CreateComment(context.Context, *CreateCommentRequest) (*CommentResponse, error)
 	ListComment(context.Context, *ListCommentRequest) (*CommentsResponse, error)
+	UpdateComment(context.Context, *UpdateCommentRequest) (*CommentResponse, error)
 	DeleteComment(context.Context, *GetCommentRequest) (*Response, error)
+	ListCommentRevision(context.Context, *GetCommentRequest) (*CommentRevisionsResponse, error)
+	WatchFeed(*WatchFeedRequest, RealWorld_WatchFeedServer) error
+	WatchComments(*WatchCommentsRequest, RealWorld_WatchCommentsServer) error
+	ListMention(context.Context, *ListMentionRequest) (*MentionsResponse, error)
+	ListNotification(context.Context, *ListNotificationRequest) (*NotificationsResponse, error)
+	MarkNotificationRead(context.Context, *MarkNotificationReadRequest) (*Response, error)
+	MarkAllNotificationRead(context.Context, *emptypb.Empty) (*Response, error)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*WebhookResponse, error)
+	ListWebhook(context.Context, *emptypb.Empty) (*WebhooksResponse, error)
+	DeleteWebhook(context.Context, *GetWebhookRequest) (*Response, error)
+	ListWebhookDelivery(context.Context, *ListWebhookDeliveryRequest) (*WebhookDeliveriesResponse, error)
+	RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*WebhookDeliveryResponse, error)
 	mustEmbedUnimplementedRealWorldServer()
 }
 
@@ -277,12 +468,12 @@ func (UnimplementedRealWorldServer) UnFollowUser(context.Context, *GetProfileReq
 func (UnimplementedRealWorldServer) ListArticle(context.Context, *FilterArticleRequest) (*ArticlesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListArticle not implemented")
 }
-func (UnimplementedRealWorldServer) FeedArticle(context.Context, *FilterArticleRequest) (*ArticlesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method FeedArticle not implemented")
-}
 func (UnimplementedRealWorldServer) GetArticle(context.Context, *GetArticleRequest) (*ArticleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetArticle not implemented")
 }
+func (UnimplementedRealWorldServer) FeedArticle(context.Context, *FilterArticleRequest) (*ArticlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FeedArticle not implemented")
+}
 func (UnimplementedRealWorldServer) CreateArticle(context.Context, *CreateArticleRequest) (*ArticleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateArticle not implemented")
 }
@@ -307,9 +498,48 @@ func (UnimplementedRealWorldServer) CreateComment(context.Context, *CreateCommen
 func (UnimplementedRealWorldServer) ListComment(context.Context, *ListCommentRequest) (*CommentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListComment not implemented")
 }
+func (UnimplementedRealWorldServer) UpdateComment(context.Context, *UpdateCommentRequest) (*CommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
+}
 func (UnimplementedRealWorldServer) DeleteComment(context.Context, *GetCommentRequest) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
 }
+func (UnimplementedRealWorldServer) ListCommentRevision(context.Context, *GetCommentRequest) (*CommentRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCommentRevision not implemented")
+}
+func (UnimplementedRealWorldServer) WatchFeed(*WatchFeedRequest, RealWorld_WatchFeedServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchFeed not implemented")
+}
+func (UnimplementedRealWorldServer) WatchComments(*WatchCommentsRequest, RealWorld_WatchCommentsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchComments not implemented")
+}
+func (UnimplementedRealWorldServer) ListMention(context.Context, *ListMentionRequest) (*MentionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMention not implemented")
+}
+func (UnimplementedRealWorldServer) ListNotification(context.Context, *ListNotificationRequest) (*NotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotification not implemented")
+}
+func (UnimplementedRealWorldServer) MarkNotificationRead(context.Context, *MarkNotificationReadRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkNotificationRead not implemented")
+}
+func (UnimplementedRealWorldServer) MarkAllNotificationRead(context.Context, *emptypb.Empty) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkAllNotificationRead not implemented")
+}
+func (UnimplementedRealWorldServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*WebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedRealWorldServer) ListWebhook(context.Context, *emptypb.Empty) (*WebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhook not implemented")
+}
+func (UnimplementedRealWorldServer) DeleteWebhook(context.Context, *GetWebhookRequest) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedRealWorldServer) ListWebhookDelivery(context.Context, *ListWebhookDeliveryRequest) (*WebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDelivery not implemented")
+}
+func (UnimplementedRealWorldServer) RedeliverWebhook(context.Context, *RedeliverWebhookRequest) (*WebhookDeliveryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeliverWebhook not implemented")
+}
 func (UnimplementedRealWorldServer) mustEmbedUnimplementedRealWorldServer() {}
 
 // UnsafeRealWorldServer may be embedded to opt out of forward compatibility for this service.
@@ -467,38 +697,38 @@ func _RealWorld_ListArticle_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RealWorld_FeedArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FilterArticleRequest)
+func _RealWorld_GetArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetArticleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RealWorldServer).FeedArticle(ctx, in)
+		return srv.(RealWorldServer).GetArticle(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.RealWorld/FeedArticle",
+		FullMethod: "/pb.RealWorld/GetArticle",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RealWorldServer).FeedArticle(ctx, req.(*FilterArticleRequest))
+		return srv.(RealWorldServer).GetArticle(ctx, req.(*GetArticleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RealWorld_GetArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetArticleRequest)
+func _RealWorld_FeedArticle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilterArticleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RealWorldServer).GetArticle(ctx, in)
+		return srv.(RealWorldServer).FeedArticle(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.RealWorld/GetArticle",
+		FullMethod: "/pb.RealWorld/FeedArticle",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RealWorldServer).GetArticle(ctx, req.(*GetArticleRequest))
+		return srv.(RealWorldServer).FeedArticle(ctx, req.(*FilterArticleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -647,6 +877,24 @@ func _RealWorld_ListComment_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RealWorld_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RealWorldServer).UpdateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.RealWorld/UpdateComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RealWorldServer).UpdateComment(ctx, req.(*UpdateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RealWorld_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetCommentRequest)
 	if err := dec(in); err != nil {
@@ -665,6 +913,228 @@ func _RealWorld_DeleteComment_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RealWorld_ListCommentRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RealWorldServer).ListCommentRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.RealWorld/ListCommentRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RealWorldServer).ListCommentRevision(ctx, req.(*GetCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RealWorld_WatchFeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchFeedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RealWorldServer).WatchFeed(m, &realWorldWatchFeedServer{stream})
+}
+
+type RealWorld_WatchFeedServer interface {
+	Send(*ArticleEvent) error
+	grpc.ServerStream
+}
+
+type realWorldWatchFeedServer struct {
+	grpc.ServerStream
+}
+
+func (x *realWorldWatchFeedServer) Send(m *ArticleEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RealWorld_WatchComments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCommentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RealWorldServer).WatchComments(m, &realWorldWatchCommentsServer{stream})
+}
+
+type RealWorld_WatchCommentsServer interface {
+	Send(*CommentEvent) error
+	grpc.ServerStream
+}
+
+type realWorldWatchCommentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *realWorldWatchCommentsServer) Send(m *CommentEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RealWorld_ListMention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RealWorldServer).ListMention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.RealWorld/ListMention",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RealWorldServer).ListMention(ctx, req.(*ListMentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RealWorld_ListNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RealWorldServer).ListNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.RealWorld/ListNotification",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RealWorldServer).ListNotification(ctx, req.(*ListNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RealWorld_MarkNotificationRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkNotificationReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RealWorldServer).MarkNotificationRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.RealWorld/MarkNotificationRead",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RealWorldServer).MarkNotificationRead(ctx, req.(*MarkNotificationReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RealWorld_MarkAllNotificationRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RealWorldServer).MarkAllNotificationRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.RealWorld/MarkAllNotificationRead",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RealWorldServer).MarkAllNotificationRead(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RealWorld_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RealWorldServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.RealWorld/CreateWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RealWorldServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RealWorld_ListWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RealWorldServer).ListWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.RealWorld/ListWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RealWorldServer).ListWebhook(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RealWorld_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RealWorldServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.RealWorld/DeleteWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RealWorldServer).DeleteWebhook(ctx, req.(*GetWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RealWorld_ListWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RealWorldServer).ListWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.RealWorld/ListWebhookDelivery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RealWorldServer).ListWebhookDelivery(ctx, req.(*ListWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RealWorld_RedeliverWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeliverWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RealWorldServer).RedeliverWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.RealWorld/RedeliverWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RealWorldServer).RedeliverWebhook(ctx, req.(*RedeliverWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RealWorld_ServiceDesc is the grpc.ServiceDesc for RealWorld service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -704,14 +1174,14 @@ var RealWorld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListArticle",
 			Handler:    _RealWorld_ListArticle_Handler,
 		},
-		{
-			MethodName: "FeedArticle",
-			Handler:    _RealWorld_FeedArticle_Handler,
-		},
 		{
 			MethodName: "GetArticle",
 			Handler:    _RealWorld_GetArticle_Handler,
 		},
+		{
+			MethodName: "FeedArticle",
+			Handler:    _RealWorld_FeedArticle_Handler,
+		},
 		{
 			MethodName: "CreateArticle",
 			Handler:    _RealWorld_CreateArticle_Handler,
@@ -744,11 +1214,66 @@ var RealWorld_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListComment",
 			Handler:    _RealWorld_ListComment_Handler,
 		},
+		{
+			MethodName: "UpdateComment",
+			Handler:    _RealWorld_UpdateComment_Handler,
+		},
 		{
 			MethodName: "DeleteComment",
 			Handler:    _RealWorld_DeleteComment_Handler,
 		},
+		{
+			MethodName: "ListCommentRevision",
+			Handler:    _RealWorld_ListCommentRevision_Handler,
+		},
+		{
+			MethodName: "ListMention",
+			Handler:    _RealWorld_ListMention_Handler,
+		},
+		{
+			MethodName: "ListNotification",
+			Handler:    _RealWorld_ListNotification_Handler,
+		},
+		{
+			MethodName: "MarkNotificationRead",
+			Handler:    _RealWorld_MarkNotificationRead_Handler,
+		},
+		{
+			MethodName: "MarkAllNotificationRead",
+			Handler:    _RealWorld_MarkAllNotificationRead_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _RealWorld_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhook",
+			Handler:    _RealWorld_ListWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _RealWorld_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDelivery",
+			Handler:    _RealWorld_ListWebhookDelivery_Handler,
+		},
+		{
+			MethodName: "RedeliverWebhook",
+			Handler:    _RealWorld_RedeliverWebhook_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchFeed",
+			Handler:       _RealWorld_WatchFeed_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchComments",
+			Handler:       _RealWorld_WatchComments_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "service.proto",
 }
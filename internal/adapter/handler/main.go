@@ -4,6 +4,7 @@ import (
 	"sort"
 
 	grpc_api "github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/api"
+	"github.com/labasubagia/realworld-backend/internal/adapter/handler/grpc/gateway"
 	"github.com/labasubagia/realworld-backend/internal/adapter/handler/restful"
 	"github.com/labasubagia/realworld-backend/internal/core/port"
 	"github.com/labasubagia/realworld-backend/internal/core/util"
@@ -14,6 +15,8 @@ const defaultType = restful.TypeRestful
 var fnNewMap = map[string]func(util.Config, port.Service, port.Logger) port.Server{
 	restful.TypeRestful: restful.NewServer,
 	grpc_api.TypeGrpc:   grpc_api.NewServer,
+	TypeAll:             NewAllServer,
+	gateway.TypeGateway: NewGatewayServer,
 }
 
 func Keys() (keys []string) {
@@ -1,7 +1,10 @@
 package restful
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/labasubagia/realworld-backend/internal/core/domain"
@@ -10,46 +13,51 @@ import (
 )
 
 func (server *Server) ListArticle(c *gin.Context) {
-	Tag := c.Query("tag")
-	Author := c.Query("author")
-	FavoritedBy := c.Query("favorited")
-
 	offset, limit := getPagination(c)
 	authArg, _ := getAuthArg(c)
 
+	createdAfter, err := getTimeQuery(c, "created_after")
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+	createdBefore, err := getTimeQuery(c, "created_before")
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
 	arg := port.ListArticleParams{
-		Tags:           []string{},
-		AuthorNames:    []string{},
-		FavoritedNames: []string{},
+		Tags:           getQueryArray(c, "tag"),
+		TagMode:        c.Query("tag_mode"),
+		AuthorNames:    getQueryArray(c, "author"),
+		FavoritedNames: getQueryArray(c, "favorited"),
+		CreatedAfter:   createdAfter,
+		CreatedBefore:  createdBefore,
+		Sort:           c.Query("sort"),
+		Cursor:         c.Query("cursor"),
 		AuthArg:        authArg,
 		Offset:         offset,
 		Limit:          limit,
 	}
-	if Tag != "" {
-		arg.Tags = append(arg.Tags, Tag)
-	}
-	if Author != "" {
-		arg.AuthorNames = append(arg.AuthorNames, Author)
-	}
-	if FavoritedBy != "" {
-		arg.FavoritedNames = append(arg.FavoritedNames, FavoritedBy)
-	}
 
-	articles, err := server.service.Article().List(c, arg)
+	result, err := server.service.Article().List(c, arg)
 	if err != nil {
 		errorHandler(c, err)
 		return
 	}
 
-	res := ArticlesResponse{
-		Articles: []Article{},
-		Count:    len(articles),
-	}
-	for _, article := range articles {
-		res.Articles = append(res.Articles, serializeArticle(article))
+	// latest change among listed articles
+	var lastModified time.Time
+	for _, article := range result.Articles {
+		if article.UpdatedAt.After(lastModified) {
+			lastModified = article.UpdatedAt
+		}
 	}
 
-	c.JSON(http.StatusOK, res)
+	res := serializeArticles(result)
+
+	conditionalJSON(c, http.StatusOK, res, lastModified)
 }
 
 func (server *Server) FeedArticle(c *gin.Context) {
@@ -62,22 +70,18 @@ func (server *Server) FeedArticle(c *gin.Context) {
 
 	arg := port.ListArticleParams{
 		AuthArg: authArg,
+		Sort:    c.Query("sort"),
+		Cursor:  c.Query("cursor"),
 		Offset:  offset,
 		Limit:   limit,
 	}
-	articles, err := server.service.Article().Feed(c, arg)
+	result, err := server.service.Article().Feed(c, arg)
 	if err != nil {
 		errorHandler(c, err)
 		return
 	}
 
-	res := ArticlesResponse{
-		Articles: []Article{},
-		Count:    len(articles),
-	}
-	for _, article := range articles {
-		res.Articles = append(res.Articles, serializeArticle(article))
-	}
+	res := serializeArticles(result)
 
 	c.JSON(http.StatusOK, res)
 }
@@ -95,8 +99,12 @@ func (server *Server) GetArticle(c *gin.Context) {
 		return
 	}
 
-	res := ArticleResponse{serializeArticle(article)}
-	c.JSON(http.StatusOK, res)
+	body, err := json.Marshal(ArticleResponse{serializeArticle(article)})
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+	conditionalBody(c, http.StatusOK, body, articleETag(article, body), article.UpdatedAt)
 }
 
 type CreateArticle struct {
@@ -145,6 +153,7 @@ type UpdateArticle struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Body        string `json:"body"`
+	Version     int    `json:"version"` // expected version, conflict when changed
 }
 
 type UpdateArticleRequest struct {
@@ -165,22 +174,45 @@ func (server *Server) UpdateArticle(c *gin.Context) {
 		return
 	}
 
-	article, err := server.service.Article().Update(c, port.UpdateArticleParams{
+	arg := port.UpdateArticleParams{
 		AuthArg: authArg,
 		Slug:    slug,
 		Article: domain.Article{
 			Title:       req.Article.Title,
 			Description: req.Article.Description,
 			Body:        req.Article.Body,
+			Version:     req.Article.Version,
 		},
-	})
+	}
+
+	// optimistic concurrency, article content read by client must be the current one
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		current, err := server.service.Article().Get(c, port.GetArticleParams{AuthArg: authArg, Slug: slug})
+		if err != nil {
+			errorHandler(c, err)
+			return
+		}
+		if !matchArticleContent(ifMatch, current) {
+			errorHandler(c, exception.New(exception.TypePreconditionFailed, "article has been modified", nil))
+			return
+		}
+		arg.UnmodifiedSince = current.UpdatedAt
+	}
+
+	article, err := server.service.Article().Update(c, arg)
 	if err != nil {
 		errorHandler(c, err)
 		return
 	}
 
 	res := ArticleResponse{serializeArticle(article)}
-	c.JSON(http.StatusOK, res)
+	body, err := json.Marshal(res)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+	setValidators(c, articleETag(article, body), article.UpdatedAt)
+	c.Data(http.StatusOK, gin.MIMEJSON+"; charset=utf-8", body)
 }
 
 func (server *Server) DeleteArticle(c *gin.Context) {
@@ -220,12 +252,20 @@ func (server *Server) AddComment(c *gin.Context) {
 		errorHandler(c, err)
 		return
 	}
+	if req.Comment.ParentID != "" {
+		if _, err := domain.ParseID(req.Comment.ParentID.String()); err != nil {
+			err = exception.Validation().AddError("parentId", "should valid id")
+			errorHandler(c, err)
+			return
+		}
+	}
 
 	result, err := server.service.Article().AddComment(c, port.AddCommentParams{
 		AuthArg: authArg,
 		Slug:    slug,
 		Comment: domain.Comment{
-			Body: req.Comment.Body,
+			ParentID: req.Comment.ParentID,
+			Body:     req.Comment.Body,
 		},
 	})
 	if err != nil {
@@ -241,9 +281,22 @@ func (server *Server) ListComments(c *gin.Context) {
 	slug := c.Param("slug")
 	authArg, _ := getAuthArg(c)
 
-	comments, err := server.service.Article().ListComments(c, port.ListCommentParams{
+	// no limit by default, return all comments
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil {
+		limit = 0
+	}
+
+	// flat list by default, replies refer parent by id
+	tree, _ := strconv.ParseBool(c.Query("tree"))
+
+	result, err := server.service.Article().ListComments(c, port.ListCommentParams{
 		AuthArg: authArg,
 		Slug:    slug,
+		Tree:    tree,
+		Sort:    c.Query("sort"),
+		Cursor:  c.Query("cursor"),
+		Limit:   limit,
 	})
 	if err != nil {
 		errorHandler(c, err)
@@ -251,14 +304,58 @@ func (server *Server) ListComments(c *gin.Context) {
 	}
 
 	res := CommentsResponse{
-		Comments: []Comment{},
+		Comments:   []Comment{},
+		Count:      result.Count,
+		NextCursor: result.NextCursor,
 	}
-	for _, comment := range comments {
+	for _, comment := range result.Comments {
 		res.Comments = append(res.Comments, serializeComment(comment))
 	}
 	c.JSON(http.StatusOK, res)
 }
 
+type UpdateCommentRequest struct {
+	Comment Comment `json:"comment"`
+}
+
+func (server *Server) UpdateComment(c *gin.Context) {
+	slug := c.Param("slug")
+	commentID, err := domain.ParseID(c.Param("comment_id"))
+	if err != nil {
+		err = exception.Validation().AddError("comment_id", "should valid id")
+		errorHandler(c, err)
+		return
+	}
+
+	authArg, err := getAuthArg(c)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	var req UpdateCommentRequest
+	if err := c.BindJSON(&req); err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	result, err := server.service.Article().UpdateComment(c, port.UpdateCommentParams{
+		AuthArg:   authArg,
+		Slug:      slug,
+		CommentID: commentID,
+		Comment: domain.Comment{
+			Body: req.Comment.Body,
+		},
+	})
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	res := CommentResponse{serializeComment(result)}
+	c.JSON(http.StatusOK, res)
+}
+
 func (server *Server) DeleteComment(c *gin.Context) {
 	slug := c.Param("slug")
 	commentID, err := domain.ParseID(c.Param("comment_id"))
@@ -287,6 +384,63 @@ func (server *Server) DeleteComment(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"status": "OK"})
 }
 
+func (server *Server) ListCommentRevisions(c *gin.Context) {
+	slug := c.Param("slug")
+	commentID, err := domain.ParseID(c.Param("comment_id"))
+	if err != nil {
+		err = exception.Validation().AddError("comment_id", "should valid id")
+		errorHandler(c, err)
+		return
+	}
+
+	authArg, err := getAuthArg(c)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	revisions, err := server.service.Article().ListCommentRevisions(c, port.ListCommentRevisionParams{
+		AuthArg:   authArg,
+		Slug:      slug,
+		CommentID: commentID,
+	})
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	res := CommentRevisionsResponse{Revisions: []CommentRevision{}}
+	for _, revision := range revisions {
+		res.Revisions = append(res.Revisions, serializeCommentRevision(revision))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (server *Server) ListMentions(c *gin.Context) {
+	offset, limit := getPagination(c)
+	authArg, err := getAuthArg(c)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	mentions, err := server.service.Article().ListMentions(c, port.ListMentionParams{
+		AuthArg: authArg,
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	res := MentionsResponse{Mentions: []Mention{}}
+	for _, mention := range mentions {
+		res.Mentions = append(res.Mentions, serializeMention(mention))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
 func (server *Server) AddFavoriteArticle(c *gin.Context) {
 	slug := c.Param("slug")
 	authArg, err := getAuthArg(c)
@@ -340,5 +494,5 @@ func (server *Server) ListTags(c *gin.Context) {
 		errorHandler(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"tags": tags})
+	conditionalJSON(c, http.StatusOK, gin.H{"tags": tags}, time.Time{})
 }
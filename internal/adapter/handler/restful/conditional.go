@@ -0,0 +1,97 @@
+package restful
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+// computeETag strong etag from response body
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf(`"%x"`, sum[:16])
+}
+
+// articleETag strong etag of article body, led by content validator of id and version,
+// so edit precondition is not failed by favorite count or viewer state
+func articleETag(article domain.Article, body []byte) string {
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf(`"%s-%d-%x"`, article.ID, article.Version, sum[:8])
+}
+
+// matchArticleContent check If-Match header value against content validator of article etag
+func matchArticleContent(header string, article domain.Article) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+	prefix := fmt.Sprintf(`"%s-%d-`, article.ID, article.Version)
+	for _, value := range strings.Split(header, ",") {
+		// weak etag has W/ before prefix, never match
+		if strings.HasPrefix(strings.TrimSpace(value), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchETag check etag against If-Match or If-None-Match header value,
+// weak etag only match when weak comparison allowed
+func matchETag(header, etag string, weak bool) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	for _, value := range strings.Split(header, ",") {
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, "W/") {
+			if !weak {
+				continue
+			}
+			value = strings.TrimPrefix(value, "W/")
+		}
+		if value == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// setValidators set ETag and Last-Modified of body.
+// Response depends on viewer, so vary by authorization
+func setValidators(c *gin.Context, etag string, lastModified time.Time) {
+	c.Header("ETag", etag)
+	c.Header("Vary", "Authorization")
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// conditionalJSON respond body with validators, or 304 when If-None-Match match.
+// If-Modified-Since is ignored, Last-Modified cannot tell removed items
+func conditionalJSON(c *gin.Context, code int, res any, lastModified time.Time) {
+	body, err := json.Marshal(res)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+	conditionalBody(c, code, body, computeETag(body), lastModified)
+}
+
+func conditionalBody(c *gin.Context, code int, body []byte, etag string, lastModified time.Time) {
+	setValidators(c, etag, lastModified)
+	if matchETag(c.GetHeader("If-None-Match"), etag, true) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(code, gin.MIMEJSON+"; charset=utf-8", body)
+}
@@ -28,6 +28,14 @@ func errorHandler(c *gin.Context, err error) {
 		statusCode = http.StatusUnauthorized
 	case exception.TypeValidation:
 		statusCode = http.StatusUnprocessableEntity
+	case exception.TypePreconditionFailed:
+		statusCode = http.StatusPreconditionFailed
+	case exception.TypeConflict:
+		statusCode = http.StatusConflict
+	case exception.TypeTooManyRequests:
+		statusCode = http.StatusTooManyRequests
+	case exception.TypeUnavailable:
+		statusCode = http.StatusServiceUnavailable
 	default:
 		statusCode = http.StatusInternalServerError
 	}
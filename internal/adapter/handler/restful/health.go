@@ -0,0 +1,22 @@
+package restful
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Liveness process is able to serve request, dependencies not checked
+func (server *Server) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// Readiness database is reachable and migrated, otherwise 503 so no traffic routed
+func (server *Server) Readiness(c *gin.Context) {
+	health, err := server.service.Health().Ready(c)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, serializeHealth(health, err))
+		return
+	}
+	c.JSON(http.StatusOK, serializeHealth(health, nil))
+}
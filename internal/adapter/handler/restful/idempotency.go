@@ -0,0 +1,103 @@
+package restful
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotencyReplayedHeader = "Idempotent-Replayed"
+)
+
+// responseRecorder keep copy of written body, so it can be stored for replay
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
+
+// Idempotency replay stored response of request retried with the same Idempotency-Key header,
+// only success response is stored, so failed request can be retried with the same key
+func (server *Server) Idempotency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		authArg, err := getAuthArg(c)
+		if err != nil {
+			errorHandler(c, err)
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			errorHandler(c, exception.Into(err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record, replay, err := server.service.Idempotency().Begin(c, port.BeginIdempotencyParams{
+			AuthArg:     authArg,
+			Key:         key,
+			RequestHash: domain.HashIdempotencyRequest([]byte(c.Request.Method), []byte(c.Request.URL.Path), body),
+		})
+		if err != nil {
+			errorHandler(c, err)
+			return
+		}
+		if replay {
+			c.Header(idempotencyReplayedHeader, "true")
+			c.Data(record.ResponseCode, gin.MIMEJSON+"; charset=utf-8", record.Response)
+			c.Abort()
+			return
+		}
+
+		// client disconnect must not leave key reserved
+		ctx := context.WithoutCancel(c)
+		logger := port.GetCtxSubLogger(ctx, server.logger)
+		completed := false
+		defer func() {
+			// panic or failed response, release key for retry
+			if completed {
+				return
+			}
+			if err := server.service.Idempotency().Release(ctx, record); err != nil {
+				logger.Error().Err(err).Field("idempotency_key", key).Msg("failed to release idempotency key")
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if status < 200 || status >= 300 {
+			return
+		}
+		// keep key even when store failed, request must not run twice
+		completed = true
+		record.ResponseCode = status
+		record.Response = recorder.body.Bytes()
+		if err := server.service.Idempotency().Complete(ctx, record); err != nil {
+			logger.Error().Err(err).Field("idempotency_key", key).Msg("failed to store idempotent response")
+		}
+	}
+}
@@ -3,11 +3,16 @@ package restful
 import (
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/labasubagia/realworld-backend/internal/core/domain"
 	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/metrics"
+	"github.com/labasubagia/realworld-backend/internal/core/util/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 func (s *Server) Logger() gin.HandlerFunc {
@@ -19,9 +24,22 @@ func (s *Server) Logger() gin.HandlerFunc {
 			reqID = domain.NewID().String()
 		}
 
+		// trace continue incoming traceparent, route known before handler run
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx := tracing.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := tracing.Start(ctx, c.Request.Method+" "+route,
+			attribute.String("http.request.method", c.Request.Method),
+			attribute.String("http.route", route),
+			attribute.String("request_id", reqID),
+		)
+		c.Request = c.Request.WithContext(ctx)
+
 		// make logger and sub-logger
 		// ? make unique instance for each handler/interactor request
-		logger := s.logger.NewInstance().Field("request_id", reqID).Logger()
+		logger := s.logger.NewInstance().Field("request_id", reqID).Field("trace_id", tracing.TraceID(ctx)).Logger()
 		// logger := s.logger.Field("request_id", reqID).Logger() // this is use single instance
 		c.Set(port.SubLoggerCtxKey, logger)
 
@@ -30,6 +48,15 @@ func (s *Server) Logger() gin.HandlerFunc {
 		c.Next()
 		duration := time.Since(startTime)
 
+		span.SetAttributes(attribute.Int("http.response.status_code", c.Writer.Status()))
+		if c.Writer.Status() >= 500 {
+			tracing.Fail(span, http.StatusText(c.Writer.Status()))
+		}
+		span.End()
+
+		// metrics, unmatched route share one label
+		metrics.ObserveRequest("http", route, strconv.Itoa(c.Writer.Status()), duration)
+
 		// log
 		logEvent := logger.Info()
 		if c.Writer.Status() >= 500 {
@@ -0,0 +1,97 @@
+package restful
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+)
+
+func (server *Server) ListNotifications(c *gin.Context) {
+	offset, limit := getPagination(c)
+	authArg, err := getAuthArg(c)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+	unread, _ := strconv.ParseBool(c.Query("unread"))
+
+	result, err := server.service.Notification().List(c, port.ListNotificationParams{
+		AuthArg:    authArg,
+		UnreadOnly: unread,
+		Offset:     offset,
+		Limit:      limit,
+	})
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	res := NotificationsResponse{
+		Notifications: []Notification{},
+		Count:         result.Count,
+		UnreadCount:   result.UnreadCount,
+	}
+	for _, notification := range result.Notifications {
+		res.Notifications = append(res.Notifications, serializeNotification(notification))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+type MarkNotificationReadRequest struct {
+	IDs []string `json:"ids"`
+}
+
+func (server *Server) MarkNotificationRead(c *gin.Context) {
+	authArg, err := getAuthArg(c)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	var req MarkNotificationReadRequest
+	if err := c.BindJSON(&req); err != nil {
+		errorHandler(c, err)
+		return
+	}
+	ids := []domain.ID{}
+	for _, value := range req.IDs {
+		id, err := domain.ParseID(value)
+		if err != nil {
+			err = exception.Validation().AddError("ids", "should valid id")
+			errorHandler(c, err)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	err = server.service.Notification().MarkRead(c, port.MarkNotificationReadParams{
+		AuthArg: authArg,
+		IDs:     ids,
+	})
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "OK"})
+}
+
+func (server *Server) MarkAllNotificationRead(c *gin.Context) {
+	authArg, err := getAuthArg(c)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	err = server.service.Notification().MarkAllRead(c, authArg)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "OK"})
+}
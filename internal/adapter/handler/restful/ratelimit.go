@@ -0,0 +1,54 @@
+package restful
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/labasubagia/realworld-backend/internal/core/util/ratelimit"
+)
+
+// authRoutes routes limited as auth class, target of credential guessing
+var authRoutes = map[string]bool{
+	"/users":       true,
+	"/users/login": true,
+}
+
+// RateLimit limit request of each route class,
+// caller is identified by user id when token valid, otherwise client ip,
+// which is only read from forwarded header of trusted proxies
+func (server *Server) RateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		class := ratelimit.ClassWrite
+		if authRoutes[c.FullPath()] {
+			class = ratelimit.ClassAuth
+		} else if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			class = ratelimit.ClassRead
+		}
+
+		result, err := server.limiter.Allow(c, class, server.rateLimitKey(c))
+		if err != nil {
+			// store failure should not block traffic
+			port.GetCtxSubLogger(c, server.logger).Error().Err(err).Msg("failed to check rate limit")
+			c.Next()
+			return
+		}
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+			errorHandler(c, exception.New(exception.TypeTooManyRequests, "too many requests", nil))
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimitKey identify caller by user id when token valid, otherwise client ip
+func (server *Server) rateLimitKey(c *gin.Context) string {
+	if authArg, err := server.parseToken(c); err == nil && authArg.Payload != nil {
+		return "user:" + authArg.Payload.UserID.String()
+	}
+	return "ip:" + c.ClientIP()
+}
@@ -1,6 +1,10 @@
 package restful
 
-import "github.com/labasubagia/realworld-backend/internal/core/domain"
+import (
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+)
 
 type Profile struct {
 	Username  string `json:"username"`
@@ -28,6 +32,7 @@ type User struct {
 	Bio      string `json:"bio"`
 	Image    string `json:"image"`
 	Token    string `json:"token"`
+	Version  int    `json:"version"`
 }
 
 type UserResponse struct {
@@ -45,6 +50,7 @@ func serializeUser(arg domain.User) User {
 		Bio:      arg.Bio,
 		Image:    arg.Image,
 		Token:    arg.Token,
+		Version:  arg.Version,
 	}
 }
 
@@ -59,6 +65,7 @@ type Article struct {
 	Favorited      bool     `json:"favorited"`
 	FavoritesCount int      `json:"favoritesCount"`
 	Author         Profile  `json:"author"`
+	Version        int      `json:"version"`
 }
 
 type ArticleResponse struct {
@@ -66,8 +73,21 @@ type ArticleResponse struct {
 }
 
 type ArticlesResponse struct {
-	Articles []Article `json:"articles"`
-	Count    int       `json:"articlesCount"`
+	Articles   []Article `json:"articles"`
+	Count      int       `json:"articlesCount"`
+	NextCursor string    `json:"nextCursor,omitempty"`
+}
+
+func serializeArticles(arg port.ListArticleResult) ArticlesResponse {
+	res := ArticlesResponse{
+		Articles:   []Article{},
+		Count:      arg.Count,
+		NextCursor: arg.NextCursor,
+	}
+	for _, article := range arg.Articles {
+		res.Articles = append(res.Articles, serializeArticle(article))
+	}
+	return res
 }
 
 func serializeArticle(arg domain.Article) Article {
@@ -86,15 +106,20 @@ func serializeArticle(arg domain.Article) Article {
 		Author:         serializeProfile(arg.Author),
 		CreatedAt:      timeString(arg.CreatedAt),
 		UpdatedAt:      timeString(arg.UpdatedAt),
+		Version:        arg.Version,
 	}
 }
 
 type Comment struct {
 	ID        domain.ID `json:"id"`
+	ParentID  domain.ID `json:"parentId,omitempty"`
 	CreatedAt string    `json:"createdAt"`
 	UpdatedAt string    `json:"updatedAt"`
 	Body      string    `json:"body"`
+	Deleted   bool      `json:"deleted"`
+	Edited    bool      `json:"edited"`
 	Author    Profile   `json:"author"`
+	Replies   []Comment `json:"replies,omitempty"`
 }
 
 type CommentResponse struct {
@@ -102,15 +127,214 @@ type CommentResponse struct {
 }
 
 type CommentsResponse struct {
-	Comments []Comment `json:"comments"`
+	Comments   []Comment `json:"comments"`
+	Count      int       `json:"commentsCount"`
+	NextCursor string    `json:"nextCursor,omitempty"`
 }
 
 func serializeComment(arg domain.Comment) Comment {
-	return Comment{
+	comment := Comment{
+		ID:        arg.ID,
+		ParentID:  arg.ParentID,
+		Body:      arg.Body,
+		Deleted:   arg.IsDeleted,
+		Edited:    arg.IsEdited,
+		Author:    serializeProfile(arg.Author),
+		CreatedAt: timeString(arg.CreatedAt),
+		UpdatedAt: timeString(arg.UpdatedAt),
+	}
+	for _, reply := range arg.Replies {
+		comment.Replies = append(comment.Replies, serializeComment(reply))
+	}
+	return comment
+}
+
+type CommentRevision struct {
+	ID        domain.ID `json:"id"`
+	Body      string    `json:"body"`
+	CreatedAt string    `json:"createdAt"`
+}
+
+type CommentRevisionsResponse struct {
+	Revisions []CommentRevision `json:"revisions"`
+}
+
+func serializeCommentRevision(arg domain.CommentRevision) CommentRevision {
+	return CommentRevision{
 		ID:        arg.ID,
 		Body:      arg.Body,
+		CreatedAt: timeString(arg.CreatedAt),
+	}
+}
+
+type ArticleRef struct {
+	Slug  string `json:"slug"`
+	Title string `json:"title"`
+}
+
+type Mention struct {
+	ID        domain.ID  `json:"id"`
+	Article   ArticleRef `json:"article"`
+	CommentID domain.ID  `json:"commentId,omitempty"`
+	Author    Profile    `json:"author"`
+	CreatedAt string     `json:"createdAt"`
+}
+
+type MentionsResponse struct {
+	Mentions []Mention `json:"mentions"`
+}
+
+func serializeMention(arg domain.Mention) Mention {
+	return Mention{
+		ID: arg.ID,
+		Article: ArticleRef{
+			Slug:  arg.Article.Slug,
+			Title: arg.Article.Title,
+		},
+		CommentID: arg.CommentID,
 		Author:    serializeProfile(arg.Author),
 		CreatedAt: timeString(arg.CreatedAt),
+	}
+}
+
+type Notification struct {
+	ID        domain.ID   `json:"id"`
+	Type      string      `json:"type"`
+	Read      bool        `json:"read"`
+	Actor     Profile     `json:"actor"`
+	Article   *ArticleRef `json:"article,omitempty"`
+	CommentID domain.ID   `json:"commentId,omitempty"`
+	CreatedAt string      `json:"createdAt"`
+}
+
+type NotificationResponse struct {
+	Notification Notification `json:"notification"`
+}
+
+type NotificationsResponse struct {
+	Notifications []Notification `json:"notifications"`
+	Count         int            `json:"notificationsCount"`
+	UnreadCount   int            `json:"unreadCount"`
+}
+
+func serializeNotification(arg domain.Notification) Notification {
+	notification := Notification{
+		ID:        arg.ID,
+		Type:      arg.Type,
+		Read:      arg.IsRead(),
+		Actor:     serializeProfile(arg.Actor),
+		CommentID: arg.CommentID,
+		CreatedAt: timeString(arg.CreatedAt),
+	}
+	if arg.ArticleID != "" {
+		notification.Article = &ArticleRef{
+			Slug:  arg.Article.Slug,
+			Title: arg.Article.Title,
+		}
+	}
+	return notification
+}
+
+type Webhook struct {
+	ID        domain.ID `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	CreatedAt string    `json:"createdAt"`
+	UpdatedAt string    `json:"updatedAt"`
+}
+
+type WebhookResponse struct {
+	Webhook Webhook `json:"webhook"`
+}
+
+type WebhooksResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+func serializeWebhook(arg domain.Webhook) Webhook {
+	return Webhook{
+		ID:        arg.ID,
+		URL:       arg.URL,
+		Secret:    arg.Secret,
+		Events:    arg.Events,
+		CreatedAt: timeString(arg.CreatedAt),
 		UpdatedAt: timeString(arg.UpdatedAt),
 	}
 }
+
+type WebhookDelivery struct {
+	ID           domain.ID `json:"id"`
+	Event        string    `json:"event"`
+	Payload      string    `json:"payload"`
+	Status       string    `json:"status"`
+	Attempts     int       `json:"attempts"`
+	ResponseCode int       `json:"responseCode"`
+	Error        string    `json:"error,omitempty"`
+	CreatedAt    string    `json:"createdAt"`
+	UpdatedAt    string    `json:"updatedAt"`
+}
+
+type WebhookDeliveryResponse struct {
+	Delivery WebhookDelivery `json:"delivery"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+func serializeWebhookDelivery(arg domain.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:           arg.ID,
+		Event:        arg.Event,
+		Payload:      arg.Payload,
+		Status:       arg.Status,
+		Attempts:     arg.Attempts,
+		ResponseCode: arg.ResponseCode,
+		Error:        arg.Error,
+		CreatedAt:    timeString(arg.CreatedAt),
+		UpdatedAt:    timeString(arg.UpdatedAt),
+	}
+}
+
+// serializeEvent event data using same shape as single item response
+func serializeEvent(arg domain.Event) any {
+	switch data := arg.Data.(type) {
+	case domain.Article:
+		return ArticleResponse{serializeArticle(data)}
+	case domain.Comment:
+		return CommentResponse{serializeComment(data)}
+	case domain.Notification:
+		return NotificationResponse{serializeNotification(data)}
+	}
+	return arg.Data
+}
+
+type Migration struct {
+	Version uint `json:"version"`
+	Latest  uint `json:"latest"`
+	Dirty   bool `json:"dirty"`
+}
+
+type HealthResponse struct {
+	Status    string     `json:"status"`
+	Database  string     `json:"database,omitempty"`
+	Migration *Migration `json:"migration,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+func serializeHealth(arg port.RepositoryHealth, err error) HealthResponse {
+	res := HealthResponse{Status: "ok", Database: arg.Database}
+	if arg.LatestMigration > 0 {
+		res.Migration = &Migration{
+			Version: arg.MigrationVersion,
+			Latest:  arg.LatestMigration,
+			Dirty:   arg.MigrationDirty,
+		}
+	}
+	if err != nil {
+		res.Status = "unavailable"
+		res.Error = exception.Into(err).Message
+	}
+	return res
+}
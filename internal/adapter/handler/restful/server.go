@@ -2,26 +2,32 @@ package restful
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
-	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/labasubagia/realworld-backend/internal/core/port"
 	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/metrics"
+	"github.com/labasubagia/realworld-backend/internal/core/util/ratelimit"
 )
 
-const TypeRestful = "restful"
+const (
+	TypeRestful = "restful"
+)
 
 type Server struct {
 	config  util.Config
 	router  *gin.Engine
 	service port.Service
 	logger  port.Logger
+	limiter *ratelimit.Limiter
+	done    chan struct{} // closed on shutdown, end open streams
+
+	socketUpgrader websocket.Upgrader
 }
 
 func NewServer(config util.Config, service port.Service, logger port.Logger) port.Server {
@@ -29,7 +35,10 @@ func NewServer(config util.Config, service port.Service, logger port.Logger) por
 		config:  config,
 		service: service,
 		logger:  logger,
+		limiter: ratelimit.New(config),
+		done:    make(chan struct{}),
 	}
+	server.socketUpgrader = server.newSocketUpgrader()
 	server.setupRouter()
 	return server
 }
@@ -38,8 +47,23 @@ func (server *Server) setupRouter() {
 
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
+	// gin context fallback to request context, so handlers pass trace span to service
+	router.ContextWithFallback = true
+	// client ip is taken from X-Forwarded-For only when sent by trusted proxy,
+	// otherwise header is spoofable and rate limit bypassed
+	if err := router.SetTrustedProxies(server.config.TrustedProxies); err != nil {
+		server.logger.Fatal().Err(err).Msg("invalid trusted proxies")
+	}
 
-	router.Use(server.Logger(), gin.Recovery(), cors.Default())
+	// api authorize with bearer token, so any origin allowed unless configured
+	corsConfig := cors.DefaultConfig()
+	corsConfig.AllowAllOrigins = len(server.config.CorsAllowedOrigins) == 0
+	corsConfig.AllowOrigins = server.config.CorsAllowedOrigins
+	if err := corsConfig.Validate(); err != nil {
+		server.logger.Fatal().Err(err).Msg("invalid cors allowed origins")
+	}
+
+	router.Use(server.Logger(), gin.Recovery(), cors.New(corsConfig), server.RateLimit())
 
 	router.NoRoute(func(ctx *gin.Context) {
 		ctx.JSON(http.StatusNotFound, gin.H{"message": "page not found"})
@@ -51,6 +75,12 @@ func (server *Server) setupRouter() {
 	router.GET("/", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{"message": "Hello World!"})
 	})
+	router.GET("/healthz", server.Liveness)
+	router.GET("/readyz", server.Readiness)
+	// without admin port, metrics stay on api port for scraper
+	if server.config.AdminPort == 0 {
+		router.GET("/metrics", gin.WrapH(metrics.Handler()))
+	}
 	router.POST("/users", server.Register)
 	router.POST("/users/login", server.Login)
 
@@ -58,6 +88,15 @@ func (server *Server) setupRouter() {
 	userRouter.Use(server.AuthMiddleware(true))
 	userRouter.GET("/", server.CurrentUser)
 	userRouter.PUT("/", server.UpdateUser)
+	userRouter.GET("/mentions", server.ListMentions)
+	userRouter.GET("/notifications", server.ListNotifications)
+	userRouter.POST("/notifications/read", server.MarkNotificationRead)
+	userRouter.POST("/notifications/read-all", server.MarkAllNotificationRead)
+	userRouter.POST("/webhooks", server.CreateWebhook)
+	userRouter.GET("/webhooks", server.ListWebhooks)
+	userRouter.DELETE("/webhooks/:webhook_id", server.DeleteWebhook)
+	userRouter.GET("/webhooks/:webhook_id/deliveries", server.ListWebhookDeliveries)
+	userRouter.POST("/webhooks/:webhook_id/deliveries/:delivery_id/redeliver", server.RedeliverWebhook)
 
 	profileRouter := router.Group("/profiles/:username")
 	profileRouter.Use(server.AuthMiddleware(false))
@@ -70,14 +109,17 @@ func (server *Server) setupRouter() {
 	articleRouter.GET("/", server.ListArticle)
 	articleRouter.GET("/feed", server.FeedArticle)
 	articleRouter.GET("/:slug", server.GetArticle)
-	articleRouter.POST("/", server.CreateArticle)
+	articleRouter.POST("/", server.Idempotency(), server.CreateArticle)
 	articleRouter.PUT("/:slug", server.UpdateArticle)
 	articleRouter.DELETE("/:slug", server.DeleteArticle)
 
 	commentRouter := articleRouter.Group("/:slug/comments")
-	commentRouter.POST("/", server.AddComment)
+	commentRouter.POST("/", server.Idempotency(), server.AddComment)
 	commentRouter.GET("/", server.ListComments)
+	commentRouter.GET("/ws", server.CommentSocket)
+	commentRouter.PUT("/:comment_id", server.UpdateComment)
 	commentRouter.DELETE("/:comment_id", server.DeleteComment)
+	commentRouter.GET("/:comment_id/revisions", server.ListCommentRevisions)
 
 	favoriteArticleRouter := articleRouter.Group("/:slug/favorite")
 	favoriteArticleRouter.POST("/", server.AddFavoriteArticle)
@@ -86,38 +128,73 @@ func (server *Server) setupRouter() {
 	tagRouter := router.Group("/tags")
 	tagRouter.GET("/", server.ListTags)
 
+	streamRouter := router.Group("/stream")
+	streamRouter.Use(server.AuthMiddleware(true))
+	streamRouter.GET("/", server.Stream)
+
 	server.router = router
 }
 
-func (server *Server) Start() error {
+func (server *Server) Start(ctx context.Context) error {
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", server.config.ServerPort),
 		Handler: server.router,
 	}
+	// shutdown wait active connections, streams never end by itself
+	srv.RegisterOnShutdown(func() {
+		close(server.done)
+	})
+
+	// metrics and debug vars are internal, only served on admin port
+	var admin *http.Server
+	if server.config.AdminPort > 0 {
+		admin = server.newAdmin()
+		go func() {
+			server.logger.Info().Msgf("admin server listen to port %d", server.config.AdminPort)
+			if err := admin.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				server.logger.Error().Err(err).Msg("failed to serve admin")
+			}
+		}()
+	}
 
+	listenErr := make(chan error, 1)
 	go func() {
+		server.logger.Info().Msgf("restful server listen to port %d", server.config.ServerPort)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			server.logger.Fatal().Err(err).Msg("failed listen")
+			listenErr <- err
 		}
 	}()
 
-	quit := make(chan os.Signal)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-	server.logger.Info().Msg("shutdown server...")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := srv.Shutdown(ctx); err != nil {
-		server.logger.Fatal().Err(err).Msg("failed shutdown server")
-	}
-
 	select {
+	case err := <-listenErr:
+		return err
 	case <-ctx.Done():
-		server.logger.Info().Msg("timeout in 5 seconds")
 	}
-	server.logger.Info().Msg("server exiting")
+	server.logger.Info().Msg("shutdown restful server...")
 
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), server.config.DrainTimeout())
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if admin != nil {
+		if err := admin.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+	}
+	server.logger.Info().Msg("restful server exiting")
 	return nil
 }
+
+func (server *Server) newAdmin() *http.Server {
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+	router.GET("/debug/vars", gin.WrapH(expvar.Handler()))
+	router.GET("/healthz", server.Liveness)
+	router.GET("/readyz", server.Readiness)
+	return &http.Server{
+		Addr:    fmt.Sprintf(":%d", server.config.AdminPort),
+		Handler: router,
+	}
+}
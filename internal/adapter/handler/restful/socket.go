@@ -0,0 +1,227 @@
+package restful
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/labasubagia/realworld-backend/internal/core/util/ratelimit"
+)
+
+const (
+	SocketTypeComment        = domain.EventTypeComment
+	SocketTypeCommentDeleted = domain.EventTypeCommentDeleted
+	SocketTypeError          = "error"
+)
+
+const (
+	socketWriteWait      = 10 * time.Second
+	socketPongWait       = 60 * time.Second
+	socketPingPeriod     = socketPongWait * 9 / 10
+	socketMaxMessageSize = 16 * 1024
+	socketSendBufferSize = 16
+)
+
+func (server *Server) newSocketUpgrader() websocket.Upgrader {
+	return websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     server.checkSocketOrigin,
+	}
+}
+
+// checkSocketOrigin allow same host and cors allowed origins,
+// browser send credential of other site on websocket so origin is not open to all like cors default
+func (server *Server) checkSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range server.config.CorsAllowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// SocketMessage sent both ways, client post comment with type comment
+type SocketMessage struct {
+	Type    string        `json:"type"`
+	ID      string        `json:"id,omitempty"`
+	Comment *Comment      `json:"comment,omitempty"`
+	Errors  exception.Err `json:"errors,omitempty"`
+}
+
+func (server *Server) CommentSocket(c *gin.Context) {
+	slug := c.Param("slug")
+	authArg, _ := getAuthArg(c)
+
+	sub, err := server.service.Event().SubscribeComments(c, port.SubscribeCommentParams{
+		Slug:        slug,
+		LastEventID: c.Query("last_event_id"),
+	})
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+	defer sub.Close()
+
+	// upgrader already reply error response
+	conn, err := server.socketUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+
+	// replies of client messages, reader stop when client does not read them
+	send := make(chan SocketMessage, socketSendBufferSize)
+	readDone := make(chan struct{})
+	ctx := c.Request.Context()
+	rateKey := server.rateLimitKey(c)
+	go func() {
+		defer close(readDone)
+		server.readCommentSocket(ctx, conn, authArg, rateKey, slug, send)
+	}()
+	defer func() {
+		conn.Close()
+		<-readDone
+	}()
+
+	ping := time.NewTicker(socketPingPeriod)
+	defer ping.Stop()
+
+	for {
+		var msg SocketMessage
+		select {
+		case <-readDone:
+			return
+		case <-server.done:
+			closeSocket(conn, websocket.CloseGoingAway, "server shutdown")
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(socketWriteWait)); err != nil {
+				return
+			}
+			continue
+		case event, ok := <-sub.Events():
+			// closed by broker when client is too slow, client can resume with last event id
+			if !ok {
+				closeSocket(conn, websocket.CloseTryAgainLater, "too slow")
+				return
+			}
+			msg = serializeSocketEvent(event)
+		case msg = <-send:
+		}
+
+		conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+		if err := conn.WriteJSON(msg); err != nil {
+			return
+		}
+	}
+}
+
+func (server *Server) readCommentSocket(ctx context.Context, conn *websocket.Conn, authArg port.AuthParams, rateKey, slug string, send chan<- SocketMessage) {
+	conn.SetReadLimit(socketMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req SocketMessage
+		var reply *SocketMessage
+		if err := json.Unmarshal(data, &req); err != nil {
+			reply = serializeSocketError(exception.Validation().AddError("message", "should valid json"))
+		} else {
+			reply = server.handleSocketMessage(ctx, authArg, rateKey, slug, req)
+		}
+		if reply == nil {
+			continue
+		}
+
+		select {
+		case send <- *reply:
+		default:
+			return
+		}
+	}
+}
+
+// handleSocketMessage return reply to sender, new comment is broadcast to all subscribers.
+// Upgrade request is limited once as read, so every message is limited as write
+func (server *Server) handleSocketMessage(ctx context.Context, authArg port.AuthParams, rateKey, slug string, req SocketMessage) *SocketMessage {
+	result, err := server.limiter.Allow(ctx, ratelimit.ClassWrite, rateKey)
+	if err != nil {
+		// store failure should not block traffic
+		port.GetCtxSubLogger(ctx, server.logger).Error().Err(err).Msg("failed to check rate limit")
+	} else if !result.Allowed {
+		return serializeSocketError(exception.New(exception.TypeTooManyRequests, "too many requests", nil))
+	}
+
+	if req.Type != SocketTypeComment {
+		return serializeSocketError(exception.Validation().AddError("type", "should be comment"))
+	}
+	if req.Comment == nil {
+		return serializeSocketError(exception.Validation().AddError("comment", "required"))
+	}
+	if req.Comment.ParentID != "" {
+		if _, err := domain.ParseID(req.Comment.ParentID.String()); err != nil {
+			return serializeSocketError(exception.Validation().AddError("parentId", "should valid id"))
+		}
+	}
+
+	_, err = server.service.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: authArg,
+		Slug:    slug,
+		Comment: domain.Comment{
+			ParentID: req.Comment.ParentID,
+			Body:     req.Comment.Body,
+		},
+	})
+	if err != nil {
+		return serializeSocketError(err)
+	}
+	return nil
+}
+
+func serializeSocketEvent(event domain.Event) SocketMessage {
+	msg := SocketMessage{Type: event.Type, ID: event.ID}
+	if comment, ok := event.Data.(domain.Comment); ok {
+		serialized := serializeComment(comment)
+		msg.Comment = &serialized
+	}
+	return msg
+}
+
+func serializeSocketError(err error) *SocketMessage {
+	fail := exception.Into(err)
+	if !fail.HasError() {
+		fail.AddError("exception", fail.Message)
+	}
+	return &SocketMessage{Type: SocketTypeError, Errors: fail.Errors}
+}
+
+func closeSocket(conn *websocket.Conn, code int, text string) {
+	msg := websocket.FormatCloseMessage(code, text)
+	conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(socketWriteWait))
+}
@@ -93,6 +93,7 @@ type UpdateUser struct {
 	Password string `json:"password,omitempty"`
 	Bio      string `json:"bio,omitempty"`
 	Image    string `json:"image,omitempty"`
+	Version  int    `json:"version,omitempty"` // expected version, conflict when changed
 }
 
 type UpdateUserRequest struct {
@@ -119,6 +120,7 @@ func (server *Server) UpdateUser(c *gin.Context) {
 			Password: req.User.Password,
 			Image:    req.User.Image,
 			Bio:      req.User.Bio,
+			Version:  req.User.Version,
 		},
 	})
 	if err != nil {
@@ -141,7 +143,7 @@ func (server *Server) Profile(c *gin.Context) {
 		return
 	}
 	res := ProfileResponse{serializeProfile(user)}
-	c.JSON(http.StatusOK, res)
+	conditionalJSON(c, http.StatusOK, res, user.UpdatedAt)
 }
 
 func (server *Server) FollowUser(c *gin.Context) {
@@ -72,6 +72,31 @@ func getPagination(c *gin.Context) (offset, limit int) {
 	return offset, limit
 }
 
+// getQueryArray get repeated query (?key=a&key=b) without empty values
+func getQueryArray(c *gin.Context, key string) []string {
+	values := []string{}
+	for _, value := range c.QueryArray(key) {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getTimeQuery parse RFC3339 or date (YYYY-MM-DD) query, zero time when empty
+func getTimeQuery(c *gin.Context, key string) (time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, exception.Validation().AddError(key, "must be RFC3339 or YYYY-MM-DD")
+}
+
 func timeString(t time.Time) string {
 	return t.UTC().Format(formatTime)
 }
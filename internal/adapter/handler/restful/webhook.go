@@ -0,0 +1,159 @@
+package restful
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+)
+
+type CreateWebhook struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+type CreateWebhookRequest struct {
+	Webhook CreateWebhook `json:"webhook"`
+}
+
+func (server *Server) CreateWebhook(c *gin.Context) {
+	authArg, err := getAuthArg(c)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	var req CreateWebhookRequest
+	if err := c.BindJSON(&req); err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	webhook, err := server.service.Webhook().Create(c, port.CreateWebhookParams{
+		AuthArg: authArg,
+		Webhook: domain.Webhook{
+			URL:    req.Webhook.URL,
+			Events: req.Webhook.Events,
+		},
+	})
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	res := WebhookResponse{serializeWebhook(webhook)}
+	c.JSON(http.StatusCreated, res)
+}
+
+func (server *Server) ListWebhooks(c *gin.Context) {
+	authArg, err := getAuthArg(c)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	webhooks, err := server.service.Webhook().List(c, authArg)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	res := WebhooksResponse{Webhooks: []Webhook{}}
+	for _, webhook := range webhooks {
+		webhook.Secret = "" // secret only shown on create
+		res.Webhooks = append(res.Webhooks, serializeWebhook(webhook))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (server *Server) DeleteWebhook(c *gin.Context) {
+	webhookID, err := domain.ParseID(c.Param("webhook_id"))
+	if err != nil {
+		err = exception.Validation().AddError("webhook_id", "should valid id")
+		errorHandler(c, err)
+		return
+	}
+	authArg, err := getAuthArg(c)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	err = server.service.Webhook().Delete(c, port.DeleteWebhookParams{
+		AuthArg:   authArg,
+		WebhookID: webhookID,
+	})
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "OK"})
+}
+
+func (server *Server) ListWebhookDeliveries(c *gin.Context) {
+	offset, limit := getPagination(c)
+	webhookID, err := domain.ParseID(c.Param("webhook_id"))
+	if err != nil {
+		err = exception.Validation().AddError("webhook_id", "should valid id")
+		errorHandler(c, err)
+		return
+	}
+	authArg, err := getAuthArg(c)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	deliveries, err := server.service.Webhook().ListDeliveries(c, port.ListWebhookDeliveryParams{
+		AuthArg:   authArg,
+		WebhookID: webhookID,
+		Offset:    offset,
+		Limit:     limit,
+	})
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	res := WebhookDeliveriesResponse{Deliveries: []WebhookDelivery{}}
+	for _, delivery := range deliveries {
+		res.Deliveries = append(res.Deliveries, serializeWebhookDelivery(delivery))
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (server *Server) RedeliverWebhook(c *gin.Context) {
+	webhookID, err := domain.ParseID(c.Param("webhook_id"))
+	if err != nil {
+		err = exception.Validation().AddError("webhook_id", "should valid id")
+		errorHandler(c, err)
+		return
+	}
+	deliveryID, err := domain.ParseID(c.Param("delivery_id"))
+	if err != nil {
+		err = exception.Validation().AddError("delivery_id", "should valid id")
+		errorHandler(c, err)
+		return
+	}
+	authArg, err := getAuthArg(c)
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	delivery, err := server.service.Webhook().Redeliver(c, port.RedeliverWebhookParams{
+		AuthArg:    authArg,
+		WebhookID:  webhookID,
+		DeliveryID: deliveryID,
+	})
+	if err != nil {
+		errorHandler(c, err)
+		return
+	}
+
+	res := WebhookDeliveryResponse{serializeWebhookDelivery(delivery)}
+	c.JSON(http.StatusAccepted, res)
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+)
+
+const keyTags = "tags"
+
+func keyArticleSlug(slug string) string {
+	return "article:slug:" + slug
+}
+
+type articleRepo struct {
+	port.ArticleRepository
+	cache *Repository
+}
+
+func (r *articleRepo) FindOneArticle(ctx context.Context, filter port.FilterArticlePayload) (domain.Article, error) {
+	// only lookup by single slug is cached
+	slugs := filter.Slugs
+	filter.Slugs = nil
+	if len(slugs) != 1 || !reflect.ValueOf(filter).IsZero() {
+		filter.Slugs = slugs
+		return r.ArticleRepository.FindOneArticle(ctx, filter)
+	}
+	return load(ctx, r.cache, keyArticleSlug(slugs[0]), func() (domain.Article, error) {
+		return r.ArticleRepository.FindOneArticle(ctx, port.FilterArticlePayload{Slugs: slugs})
+	})
+}
+
+func (r *articleRepo) UpdateArticle(ctx context.Context, arg domain.Article) (domain.Article, error) {
+	article, err := r.ArticleRepository.UpdateArticle(ctx, arg)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	r.cache.invalidate(ctx, keyArticleSlug(arg.Slug), keyArticleSlug(article.Slug))
+	return article, nil
+}
+
+func (r *articleRepo) DeleteArticle(ctx context.Context, arg domain.Article) error {
+	if err := r.ArticleRepository.DeleteArticle(ctx, arg); err != nil {
+		return err
+	}
+	r.cache.invalidate(ctx, keyArticleSlug(arg.Slug))
+	return nil
+}
+
+func (r *articleRepo) FilterTags(ctx context.Context, filter port.FilterTagPayload) ([]domain.Tag, error) {
+	// only full tag list is cached
+	if len(filter.IDs) > 0 || len(filter.Names) > 0 {
+		return r.ArticleRepository.FilterTags(ctx, filter)
+	}
+	return load(ctx, r.cache, keyTags, func() ([]domain.Tag, error) {
+		return r.ArticleRepository.FilterTags(ctx, filter)
+	})
+}
+
+func (r *articleRepo) AddTags(ctx context.Context, arg port.AddTagsPayload) ([]domain.Tag, error) {
+	tags, err := r.ArticleRepository.AddTags(ctx, arg)
+	if err != nil {
+		return []domain.Tag{}, err
+	}
+	r.cache.invalidate(ctx, keyTags)
+	return tags, nil
+}
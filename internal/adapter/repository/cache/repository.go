@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/cache"
+)
+
+const DefaultTTL = time.Minute
+
+// counters of every cache repository, published at /debug/vars
+var expvarStats = expvar.NewMap("repository_cache")
+
+type Stats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+type counter struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// invalidation keys written inside transaction, deleted after transaction end
+type invalidation struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (i *invalidation) add(keys ...string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.keys = append(i.keys, keys...)
+}
+
+// Repository decorate repository with read-through cache of tags, article by slug and user by username.
+// Writes invalidate cached entries, ttl bound staleness of entries changed outside this process
+type Repository struct {
+	repo        port.Repository
+	backend     cache.Backend
+	ttl         time.Duration
+	logger      port.Logger
+	counter     *counter
+	tx          *invalidation // inside transaction, bypass cache read
+	userRepo    port.UserRepository
+	articleRepo port.ArticleRepository
+}
+
+// NewRepository decorate repository with in-process LRU cache
+func NewRepository(config util.Config, repo port.Repository, logger port.Logger) *Repository {
+	return NewRepositoryWithBackend(repo, cache.NewLRU(config.CacheSize), config.CacheTTL, logger)
+}
+
+func NewRepositoryWithBackend(repo port.Repository, backend cache.Backend, ttl time.Duration, logger port.Logger) *Repository {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return create(repo, backend, ttl, logger, &counter{}, nil)
+}
+
+func create(repo port.Repository, backend cache.Backend, ttl time.Duration, logger port.Logger, counter *counter, tx *invalidation) *Repository {
+	r := &Repository{
+		repo:    repo,
+		backend: backend,
+		ttl:     ttl,
+		logger:  logger,
+		counter: counter,
+		tx:      tx,
+	}
+	r.userRepo = &userRepo{UserRepository: repo.User(), cache: r}
+	r.articleRepo = &articleRepo{ArticleRepository: repo.Article(), cache: r}
+	return r
+}
+
+func (r *Repository) Atomic(ctx context.Context, fn port.RepositoryAtomicCallback) error {
+	tx := &invalidation{}
+	err := r.repo.Atomic(ctx, func(repo port.Repository) error {
+		return fn(create(repo, r.backend, r.ttl, r.logger, r.counter, tx))
+	})
+	// also on rollback, entry may be read from uncommitted change by other reader
+	r.invalidate(ctx, tx.keys...)
+	return err
+}
+
+func (r *Repository) Health(ctx context.Context) (port.RepositoryHealth, error) {
+	return r.repo.Health(ctx)
+}
+
+func (r *Repository) Close(ctx context.Context) error {
+	return r.repo.Close(ctx)
+}
+
+func (r *Repository) User() port.UserRepository {
+	return r.userRepo
+}
+
+func (r *Repository) Article() port.ArticleRepository {
+	return r.articleRepo
+}
+
+func (r *Repository) Notification() port.NotificationRepository {
+	return r.repo.Notification()
+}
+
+func (r *Repository) Webhook() port.WebhookRepository {
+	return r.repo.Webhook()
+}
+
+func (r *Repository) Outbox() port.OutboxRepository {
+	return r.repo.Outbox()
+}
+
+func (r *Repository) Idempotency() port.IdempotencyRepository {
+	return r.repo.Idempotency()
+}
+
+func (r *Repository) Stats() Stats {
+	return Stats{
+		Hits:   r.counter.hits.Load(),
+		Misses: r.counter.misses.Load(),
+	}
+}
+
+// invalidate delete keys, inside transaction deferred until transaction end
+func (r *Repository) invalidate(ctx context.Context, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	if r.tx != nil {
+		r.tx.add(keys...)
+		return
+	}
+	if err := r.backend.Delete(ctx, keys...); err != nil {
+		port.GetCtxSubLogger(ctx, r.logger).Error().Err(err).Msg("failed to invalidate cache")
+	}
+}
+
+func (r *Repository) hit() {
+	r.counter.hits.Add(1)
+	expvarStats.Add("hits", 1)
+}
+
+func (r *Repository) miss() {
+	r.counter.misses.Add(1)
+	expvarStats.Add("misses", 1)
+}
+
+// load get value from cache or load and store it, cache failure fallback to load
+func load[T any](ctx context.Context, r *Repository, key string, fn func() (T, error)) (T, error) {
+	if r.tx != nil {
+		return fn()
+	}
+	logger := port.GetCtxSubLogger(ctx, r.logger)
+
+	data, ok, err := r.backend.Get(ctx, key)
+	if err != nil {
+		logger.Error().Err(err).Field("key", key).Msg("failed to get cache")
+	}
+	if ok {
+		var value T
+		err := json.Unmarshal(data, &value)
+		if err == nil {
+			r.hit()
+			return value, nil
+		}
+		logger.Error().Err(err).Field("key", key).Msg("failed to decode cache")
+	}
+	r.miss()
+
+	value, err := fn()
+	if err != nil {
+		return value, err
+	}
+	data, err = json.Marshal(value)
+	if err != nil {
+		logger.Error().Err(err).Field("key", key).Msg("failed to encode cache")
+		return value, nil
+	}
+	if err := r.backend.Set(ctx, key, data, r.ttl); err != nil {
+		logger.Error().Err(err).Field("key", key).Msg("failed to set cache")
+	}
+	return value, nil
+}
@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+)
+
+func keyUsername(username string) string {
+	return "user:username:" + username
+}
+
+type userRepo struct {
+	port.UserRepository
+	cache *Repository
+}
+
+func (r *userRepo) FindOne(ctx context.Context, filter port.FilterUserPayload) (domain.User, error) {
+	// only lookup by single username is cached
+	if len(filter.Usernames) != 1 || len(filter.IDs) > 0 || len(filter.Emails) > 0 {
+		return r.UserRepository.FindOne(ctx, filter)
+	}
+	return load(ctx, r.cache, keyUsername(filter.Usernames[0]), func() (domain.User, error) {
+		return r.UserRepository.FindOne(ctx, filter)
+	})
+}
+
+func (r *userRepo) UpdateUser(ctx context.Context, arg domain.User) (domain.User, error) {
+	// username may change, get current one to invalidate
+	current, err := r.UserRepository.FindOne(ctx, port.FilterUserPayload{IDs: []domain.ID{arg.ID}})
+	if err != nil {
+		return domain.User{}, err
+	}
+	user, err := r.UserRepository.UpdateUser(ctx, arg)
+	if err != nil {
+		return domain.User{}, err
+	}
+	r.cache.invalidate(ctx, keyUsername(current.Username), keyUsername(user.Username))
+	return user, nil
+}
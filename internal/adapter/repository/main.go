@@ -3,6 +3,7 @@ package repository
 import (
 	"sort"
 
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/cache"
 	"github.com/labasubagia/realworld-backend/internal/adapter/repository/mongo"
 	"github.com/labasubagia/realworld-backend/internal/adapter/repository/sql"
 	"github.com/labasubagia/realworld-backend/internal/core/port"
@@ -31,15 +32,27 @@ func ListRepository(config util.Config, logger port.Logger) ([]port.Repository,
 		if err != nil {
 			return []port.Repository{}, err
 		}
-		repos = append(repos, repo)
+		repos = append(repos, withCache(config, repo, logger))
 	}
 	return repos, nil
 }
 
 func NewRepository(config util.Config, logger port.Logger) (port.Repository, error) {
 	new, ok := fnNewMap[config.DBType]
-	if ok {
-		return new(config, logger)
+	if !ok {
+		new = fnNewMap[defaultType]
 	}
-	return fnNewMap[defaultType](config, logger)
+	repo, err := new(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	return withCache(config, repo, logger), nil
+}
+
+// withCache decorate repository with in-process cache, disabled when cache size not set
+func withCache(config util.Config, repo port.Repository, logger port.Logger) port.Repository {
+	if config.CacheSize <= 0 {
+		return repo
+	}
+	return cache.NewRepository(config, repo, logger)
 }
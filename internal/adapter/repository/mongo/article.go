@@ -9,6 +9,7 @@ import (
 	"github.com/labasubagia/realworld-backend/internal/core/port"
 	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -23,24 +24,67 @@ func NewArticleRepository(db DB) port.ArticleRepository {
 }
 
 func (r *articleRepo) AddComment(ctx context.Context, arg domain.Comment) (domain.Comment, error) {
+	ctx = r.db.Context(ctx)
 	comment := model.AsComment(arg)
 	_, err := r.db.Collection(CollectionComment).InsertOne(ctx, comment)
 	if err != nil {
 		return domain.Comment{}, intoException(err)
 	}
+	if err := r.addArticleCount(ctx, comment.ArticleID, "comments_count", 1); err != nil {
+		return domain.Comment{}, intoException(err)
+	}
 	return comment.ToDomain(), nil
 }
 
+func (r *articleRepo) AddCommentRevision(ctx context.Context, arg domain.CommentRevision) (domain.CommentRevision, error) {
+	ctx = r.db.Context(ctx)
+	revision := model.AsCommentRevision(arg)
+	_, err := r.db.Collection(CollectionCommentRevision).InsertOne(ctx, revision)
+	if err != nil {
+		return domain.CommentRevision{}, intoException(err)
+	}
+	return revision.ToDomain(), nil
+}
+
 func (r *articleRepo) AddFavorite(ctx context.Context, arg domain.ArticleFavorite) (domain.ArticleFavorite, error) {
+	ctx = r.db.Context(ctx)
 	favorite := model.AsArticleFavorite(arg)
 	_, err := r.db.Collection(CollectionArticleFavorite).InsertOne(ctx, favorite)
 	if err != nil {
 		return domain.ArticleFavorite{}, intoException(err)
 	}
+	if err := r.addArticleCount(ctx, favorite.ArticleID, "favorites_count", 1); err != nil {
+		return domain.ArticleFavorite{}, intoException(err)
+	}
 	return favorite.ToDomain(), nil
 }
 
+func (r *articleRepo) AddMentions(ctx context.Context, arg []domain.Mention) ([]domain.Mention, error) {
+	ctx = r.db.Context(ctx)
+	if len(arg) == 0 {
+		return []domain.Mention{}, nil
+	}
+	mentions := make([]any, len(arg))
+	for i, mention := range arg {
+		mentions[i] = model.AsMention(mention)
+	}
+	_, err := r.db.Collection(CollectionMention).InsertMany(ctx, mentions)
+	if err != nil {
+		return []domain.Mention{}, intoException(err)
+	}
+	result := []domain.Mention{}
+	for _, mention := range mentions {
+		mention, ok := mention.(model.Mention)
+		if !ok {
+			continue
+		}
+		result = append(result, mention.ToDomain())
+	}
+	return result, nil
+}
+
 func (r *articleRepo) AddTags(ctx context.Context, arg port.AddTagsPayload) ([]domain.Tag, error) {
+	ctx = r.db.Context(ctx)
 
 	if len(arg.Tags) == 0 {
 		return []domain.Tag{}, exception.Validation().AddError("tags", "empty")
@@ -85,6 +129,7 @@ func (r *articleRepo) AddTags(ctx context.Context, arg port.AddTagsPayload) ([]d
 }
 
 func (r *articleRepo) AssignArticleTags(ctx context.Context, arg port.AssignTagPayload) ([]domain.ArticleTag, error) {
+	ctx = r.db.Context(ctx)
 	if len(arg.TagIDs) == 0 {
 		return []domain.ArticleTag{}, exception.Validation().AddError("tags", "empty")
 	}
@@ -114,6 +159,7 @@ func (r *articleRepo) AssignArticleTags(ctx context.Context, arg port.AssignTagP
 }
 
 func (r *articleRepo) CreateArticle(ctx context.Context, arg domain.Article) (domain.Article, error) {
+	ctx = r.db.Context(ctx)
 	article := model.AsArticle(arg)
 	_, err := r.db.Collection(CollectionArticle).InsertOne(ctx, article)
 	if err != nil {
@@ -123,6 +169,7 @@ func (r *articleRepo) CreateArticle(ctx context.Context, arg domain.Article) (do
 }
 
 func (r *articleRepo) DeleteArticle(ctx context.Context, arg domain.Article) error {
+	ctx = r.db.Context(ctx)
 	_, err := r.db.Collection(CollectionArticle).DeleteOne(ctx, bson.M{
 		"id":   arg.ID,
 		"slug": arg.Slug,
@@ -134,7 +181,8 @@ func (r *articleRepo) DeleteArticle(ctx context.Context, arg domain.Article) err
 }
 
 func (r *articleRepo) DeleteComment(ctx context.Context, arg domain.Comment) error {
-	_, err := r.db.Collection(CollectionComment).DeleteOne(ctx, bson.M{
+	ctx = r.db.Context(ctx)
+	res, err := r.db.Collection(CollectionComment).DeleteOne(ctx, bson.M{
 		"id":         arg.ID,
 		"author_id":  arg.AuthorID,
 		"article_id": arg.ArticleID,
@@ -142,11 +190,33 @@ func (r *articleRepo) DeleteComment(ctx context.Context, arg domain.Comment) err
 	if err != nil {
 		return intoException(err)
 	}
+	if res.DeletedCount == 0 {
+		return nil
+	}
+	if err := r.addArticleCount(ctx, arg.ArticleID, "comments_count", -1); err != nil {
+		return intoException(err)
+	}
+	_, err = r.db.Collection(CollectionCommentRevision).DeleteMany(ctx, bson.M{"comment_id": arg.ID})
+	if err != nil {
+		return intoException(err)
+	}
 	return nil
 }
 
-func (r *articleRepo) FilterArticle(ctx context.Context, arg port.FilterArticlePayload) ([]domain.Article, error) {
+func (r *articleRepo) DeleteMentions(ctx context.Context, ids []domain.ID) error {
+	ctx = r.db.Context(ctx)
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := r.db.Collection(CollectionMention).DeleteMany(ctx, bson.M{"id": bson.M{"$in": ids}})
+	if err != nil {
+		return intoException(err)
+	}
+	return nil
+}
 
+// articleQuery make filter shared by FilterArticle and CountArticle
+func articleQuery(arg port.FilterArticlePayload) []bson.M {
 	query := []bson.M{}
 	if len(arg.IDs) > 0 {
 		query = append(query, bson.M{"id": bson.M{"$in": arg.IDs}})
@@ -157,19 +227,32 @@ func (r *articleRepo) FilterArticle(ctx context.Context, arg port.FilterArticleP
 	if len(arg.Slugs) > 0 {
 		query = append(query, bson.M{"slug": bson.M{"$in": arg.Slugs}})
 	}
-	if len(arg.Slugs) > 0 {
-		query = append(query, bson.M{"slug": bson.M{"$in": arg.Slugs}})
+	if !arg.CreatedAfter.IsZero() {
+		query = append(query, bson.M{"created_at": bson.M{"$gt": arg.CreatedAfter.UTC()}})
+	}
+	if !arg.CreatedBefore.IsZero() {
+		query = append(query, bson.M{"created_at": bson.M{"$lt": arg.CreatedBefore.UTC()}})
+	}
+	return query
+}
+
+func (r *articleRepo) FilterArticle(ctx context.Context, arg port.FilterArticlePayload) ([]domain.Article, error) {
+	ctx = r.db.Context(ctx)
+
+	query := articleQuery(arg)
+	if !arg.Cursor.IsZero() {
+		operator := "$lt"
+		if arg.Sort == port.ArticleSortOldest {
+			operator = "$gt"
+		}
+		query = append(query, cursorQuery(arg.Cursor, operator))
 	}
 	filter := bson.M{}
 	if len(query) > 0 {
 		filter = bson.M{"$and": query}
 	}
 
-	limit := int64(arg.Limit)
-	offset := int64(arg.Offset)
-	option := options.FindOptions{Limit: &limit, Skip: &offset, Sort: bson.M{"id": -1}}
-
-	cursor, err := r.db.Collection(CollectionArticle).Find(ctx, filter, &option)
+	cursor, err := r.findArticle(ctx, filter, arg)
 	if err != nil {
 		return []domain.Article{}, intoException(err)
 	}
@@ -186,7 +269,53 @@ func (r *articleRepo) FilterArticle(ctx context.Context, arg port.FilterArticleP
 	return result, nil
 }
 
+// CountArticle count all articles matching filter, ignore pagination
+func (r *articleRepo) CountArticle(ctx context.Context, arg port.FilterArticlePayload) (int, error) {
+	ctx = r.db.Context(ctx)
+	filter := bson.M{}
+	if query := articleQuery(arg); len(query) > 0 {
+		filter = bson.M{"$and": query}
+	}
+	count, err := r.db.Collection(CollectionArticle).CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, intoException(err)
+	}
+	return int(count), nil
+}
+
+// findArticle find articles in requested order,
+// sort by count use denormalized counter of article
+func (r *articleRepo) findArticle(ctx context.Context, filter bson.M, arg port.FilterArticlePayload) (*mongo.Cursor, error) {
+	var sort bson.D
+	switch arg.Sort {
+	case port.ArticleSortOldest:
+		sort = bson.D{{Key: "created_at", Value: 1}, {Key: "id", Value: 1}}
+	case port.ArticleSortRecentlyUpdated:
+		sort = bson.D{{Key: "updated_at", Value: -1}, {Key: "id", Value: -1}}
+	case port.ArticleSortMostFavorited:
+		sort = bson.D{{Key: "favorites_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "id", Value: -1}}
+	case port.ArticleSortMostCommented:
+		sort = bson.D{{Key: "comments_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "id", Value: -1}}
+	default:
+		sort = bson.D{{Key: "created_at", Value: -1}, {Key: "id", Value: -1}}
+	}
+	limit := int64(arg.Limit)
+	offset := int64(arg.Offset)
+	option := options.FindOptions{Limit: &limit, Skip: &offset, Sort: sort}
+	return r.db.Collection(CollectionArticle).Find(ctx, filter, &option)
+}
+
+// cursorQuery compare (created_at, id) with cursor using operator $lt or $gt
+func cursorQuery(cursor domain.Cursor, operator string) bson.M {
+	createdAt := cursor.CreatedAt.UTC()
+	return bson.M{"$or": bson.A{
+		bson.M{"created_at": bson.M{operator: createdAt}},
+		bson.M{"created_at": createdAt, "id": bson.M{operator: cursor.ID}},
+	}}
+}
+
 func (r *articleRepo) FilterArticleTags(ctx context.Context, arg port.FilterArticleTagPayload) ([]domain.ArticleTag, error) {
+	ctx = r.db.Context(ctx)
 
 	query := []bson.M{}
 	if len(arg.ArticleIDs) > 0 {
@@ -217,20 +346,48 @@ func (r *articleRepo) FilterArticleTags(ctx context.Context, arg port.FilterArti
 	return result, nil
 }
 
-func (r *articleRepo) FilterComment(ctx context.Context, arg port.FilterCommentPayload) ([]domain.Comment, error) {
+// commentQuery make filter shared by FilterComment and CountComment
+func commentQuery(arg port.FilterCommentPayload) []bson.M {
 	query := []bson.M{}
+	if len(arg.IDs) > 0 {
+		query = append(query, bson.M{"id": bson.M{"$in": arg.IDs}})
+	}
 	if len(arg.ArticleIDs) > 0 {
 		query = append(query, bson.M{"article_id": bson.M{"$in": arg.ArticleIDs}})
 	}
 	if len(arg.AuthorIDs) > 0 {
 		query = append(query, bson.M{"author_id": bson.M{"$in": arg.AuthorIDs}})
 	}
+	if len(arg.ParentIDs) > 0 {
+		query = append(query, bson.M{"parent_id": bson.M{"$in": arg.ParentIDs}})
+	}
+	if arg.RootOnly {
+		// null also match comments created before threading
+		query = append(query, bson.M{"parent_id": bson.M{"$in": bson.A{nil, ""}}})
+	}
+	return query
+}
+
+func (r *articleRepo) FilterComment(ctx context.Context, arg port.FilterCommentPayload) ([]domain.Comment, error) {
+	ctx = r.db.Context(ctx)
+	operator, direction := "$gt", 1
+	if arg.Sort == port.CommentSortNewest {
+		operator, direction = "$lt", -1
+	}
+
+	query := commentQuery(arg)
+	if !arg.Cursor.IsZero() {
+		query = append(query, cursorQuery(arg.Cursor, operator))
+	}
 	filter := bson.M{}
 	if len(query) > 0 {
 		filter = bson.M{"$and": query}
 	}
 
-	cursor, err := r.db.Collection(CollectionComment).Find(ctx, filter)
+	limit := int64(arg.Limit)
+	option := options.FindOptions{Limit: &limit, Sort: bson.D{{Key: "created_at", Value: direction}, {Key: "id", Value: direction}}}
+
+	cursor, err := r.db.Collection(CollectionComment).Find(ctx, filter, &option)
 	if err != nil {
 		return []domain.Comment{}, intoException(err)
 	}
@@ -247,7 +404,47 @@ func (r *articleRepo) FilterComment(ctx context.Context, arg port.FilterCommentP
 	return result, nil
 }
 
+// CountComment count all comments matching filter, ignore pagination
+func (r *articleRepo) CountComment(ctx context.Context, arg port.FilterCommentPayload) (int, error) {
+	ctx = r.db.Context(ctx)
+	filter := bson.M{}
+	if query := commentQuery(arg); len(query) > 0 {
+		filter = bson.M{"$and": query}
+	}
+	count, err := r.db.Collection(CollectionComment).CountDocuments(ctx, filter)
+	if err != nil {
+		return 0, intoException(err)
+	}
+	return int(count), nil
+}
+
+func (r *articleRepo) FilterCommentRevision(ctx context.Context, arg port.FilterCommentRevisionPayload) ([]domain.CommentRevision, error) {
+	ctx = r.db.Context(ctx)
+	filter := bson.M{}
+	if len(arg.CommentIDs) > 0 {
+		filter = bson.M{"comment_id": bson.M{"$in": arg.CommentIDs}}
+	}
+
+	option := options.FindOptions{Sort: bson.D{{Key: "created_at", Value: 1}, {Key: "id", Value: 1}}}
+	cursor, err := r.db.Collection(CollectionCommentRevision).Find(ctx, filter, &option)
+	if err != nil {
+		return []domain.CommentRevision{}, intoException(err)
+	}
+
+	result := []domain.CommentRevision{}
+	for cursor.Next(ctx) {
+		data := model.CommentRevision{}
+		if err := cursor.Decode(&data); err != nil {
+			return []domain.CommentRevision{}, intoException(err)
+		}
+		result = append(result, data.ToDomain())
+	}
+
+	return result, nil
+}
+
 func (r *articleRepo) FilterFavorite(ctx context.Context, arg port.FilterFavoritePayload) ([]domain.ArticleFavorite, error) {
+	ctx = r.db.Context(ctx)
 	query := []bson.M{}
 	if len(arg.ArticleIDs) > 0 {
 		query = append(query, bson.M{"article_id": bson.M{"$in": arg.ArticleIDs}})
@@ -278,6 +475,7 @@ func (r *articleRepo) FilterFavorite(ctx context.Context, arg port.FilterFavorit
 }
 
 func (r *articleRepo) FilterFavoriteCount(ctx context.Context, arg port.FilterFavoritePayload) ([]domain.ArticleFavoriteCount, error) {
+	ctx = r.db.Context(ctx)
 
 	groupStage := bson.A{}
 
@@ -332,7 +530,43 @@ func (r *articleRepo) FilterFavoriteCount(ctx context.Context, arg port.FilterFa
 	return result, nil
 }
 
+func (r *articleRepo) FilterMention(ctx context.Context, arg port.FilterMentionPayload) ([]domain.Mention, error) {
+	ctx = r.db.Context(ctx)
+	query := []bson.M{}
+	if len(arg.UserIDs) > 0 {
+		query = append(query, bson.M{"user_id": bson.M{"$in": arg.UserIDs}})
+	}
+	if len(arg.ArticleIDs) > 0 {
+		query = append(query, bson.M{"article_id": bson.M{"$in": arg.ArticleIDs}})
+	}
+	filter := bson.M{}
+	if len(query) > 0 {
+		filter = bson.M{"$and": query}
+	}
+
+	limit := int64(arg.Limit)
+	offset := int64(arg.Offset)
+	option := options.FindOptions{Limit: &limit, Skip: &offset, Sort: bson.D{{Key: "created_at", Value: -1}, {Key: "id", Value: -1}}}
+
+	cursor, err := r.db.Collection(CollectionMention).Find(ctx, filter, &option)
+	if err != nil {
+		return []domain.Mention{}, intoException(err)
+	}
+
+	result := []domain.Mention{}
+	for cursor.Next(ctx) {
+		data := model.Mention{}
+		if err := cursor.Decode(&data); err != nil {
+			return []domain.Mention{}, intoException(err)
+		}
+		result = append(result, data.ToDomain())
+	}
+
+	return result, nil
+}
+
 func (r *articleRepo) FilterTags(ctx context.Context, arg port.FilterTagPayload) ([]domain.Tag, error) {
+	ctx = r.db.Context(ctx)
 
 	query := []bson.M{}
 	if len(arg.IDs) > 0 {
@@ -365,6 +599,7 @@ func (r *articleRepo) FilterTags(ctx context.Context, arg port.FilterTagPayload)
 }
 
 func (r *articleRepo) FindOneArticle(ctx context.Context, arg port.FilterArticlePayload) (domain.Article, error) {
+	ctx = r.db.Context(ctx)
 	articles, err := r.FilterArticle(ctx, arg)
 	if err != nil {
 		return domain.Article{}, intoException(err)
@@ -376,24 +611,40 @@ func (r *articleRepo) FindOneArticle(ctx context.Context, arg port.FilterArticle
 }
 
 func (r *articleRepo) RemoveFavorite(ctx context.Context, arg domain.ArticleFavorite) (domain.ArticleFavorite, error) {
+	ctx = r.db.Context(ctx)
 	favorite := model.AsArticleFavorite(arg)
-	_, err := r.db.Collection(CollectionArticleFavorite).DeleteOne(ctx, bson.M{
+	res, err := r.db.Collection(CollectionArticleFavorite).DeleteOne(ctx, bson.M{
 		"user_id":    arg.UserID,
 		"article_id": arg.ArticleID,
 	})
 	if err != nil {
 		return domain.ArticleFavorite{}, intoException(err)
 	}
+	if res.DeletedCount > 0 {
+		if err := r.addArticleCount(ctx, arg.ArticleID, "favorites_count", -1); err != nil {
+			return domain.ArticleFavorite{}, intoException(err)
+		}
+	}
 	return favorite.ToDomain(), nil
 }
 
+// addArticleCount change denormalized counter used to sort articles,
+// call within transaction of the counted change
+func (r *articleRepo) addArticleCount(ctx context.Context, articleID domain.ID, field string, delta int) error {
+	_, err := r.db.Collection(CollectionArticle).UpdateOne(ctx, bson.M{"id": articleID}, bson.M{
+		"$inc": bson.M{field: delta},
+	})
+	return err
+}
+
 func (r *articleRepo) UpdateArticle(ctx context.Context, arg domain.Article) (domain.Article, error) {
+	ctx = r.db.Context(ctx)
 	if arg.Title != "" {
 		arg.SetTitle(arg.Title)
 	}
 	article := model.AsArticle(arg)
 
-	filter := bson.M{"_id": arg.ID}
+	filter := bson.M{"id": arg.ID}
 
 	fields := bson.M{}
 	if arg.Title != "" {
@@ -412,10 +663,24 @@ func (r *articleRepo) UpdateArticle(ctx context.Context, arg domain.Article) (do
 		fields["updated_at"] = time.Now()
 	}
 
-	_, err := r.db.Collection(CollectionArticle).UpdateOne(ctx, filter, bson.M{"$set": fields})
+	// only expected version when provided
+	if arg.Version > 0 {
+		filter["version"] = arg.Version
+	}
+	update := bson.M{"$inc": bson.M{"version": 1}}
+	if len(fields) > 0 {
+		update["$set"] = fields
+	}
+	res, err := r.db.Collection(CollectionArticle).UpdateOne(ctx, filter, update)
 	if err != nil {
 		return domain.Article{}, intoException(err)
 	}
+	if res.MatchedCount == 0 {
+		if _, err := r.FindOneArticle(ctx, port.FilterArticlePayload{IDs: []domain.ID{article.ID}}); err != nil {
+			return domain.Article{}, intoException(err)
+		}
+		return domain.Article{}, exception.New(exception.TypeConflict, "article has been modified", nil)
+	}
 
 	// find updated
 	updated, err := r.FindOneArticle(ctx, port.FilterArticlePayload{IDs: []domain.ID{article.ID}})
@@ -425,3 +690,27 @@ func (r *articleRepo) UpdateArticle(ctx context.Context, arg domain.Article) (do
 
 	return updated, err
 }
+
+func (r *articleRepo) UpdateComment(ctx context.Context, arg domain.Comment) (domain.Comment, error) {
+	ctx = r.db.Context(ctx)
+	fields := bson.M{
+		"body":       arg.Body,
+		"is_deleted": arg.IsDeleted,
+		"is_edited":  arg.IsEdited,
+		"updated_at": arg.UpdatedAt.UTC(),
+	}
+	_, err := r.db.Collection(CollectionComment).UpdateOne(ctx, bson.M{"id": arg.ID}, bson.M{"$set": fields})
+	if err != nil {
+		return domain.Comment{}, intoException(err)
+	}
+
+	// find updated
+	comments, err := r.FilterComment(ctx, port.FilterCommentPayload{IDs: []domain.ID{arg.ID}})
+	if err != nil {
+		return domain.Comment{}, intoException(err)
+	}
+	if len(comments) == 0 {
+		return domain.Comment{}, exception.New(exception.TypeNotFound, "comment not found", nil)
+	}
+	return comments[0], nil
+}
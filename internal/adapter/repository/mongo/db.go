@@ -2,12 +2,11 @@ package mongo
 
 import (
 	"context"
-	"encoding/json"
 
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/mongo/model"
 	"github.com/labasubagia/realworld-backend/internal/core/port"
 	"github.com/labasubagia/realworld-backend/internal/core/util"
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -19,30 +18,28 @@ const (
 	CollectionTag             = "tags"
 	CollectionArticle         = "articles"
 	CollectionComment         = "comments"
+	CollectionCommentRevision = "comment_revisions"
 	CollectionArticleTag      = "article_tags"
 	CollectionArticleFavorite = "article_favorites"
+	CollectionMention         = "mentions"
+	CollectionNotification    = "notifications"
+	CollectionWebhook         = "webhooks"
+	CollectionWebhookDelivery = "webhook_deliveries"
+	CollectionOutboxEvent     = "outbox_events"
+	CollectionIdempotency     = "idempotency_keys"
 )
 
 type DB struct {
-	client *mongo.Client
-	logger port.Logger
+	client  *mongo.Client
+	logger  port.Logger
+	session mongo.Session // transaction of atomic repository, nil outside transaction
 }
 
 func NewDB(config util.Config, logger port.Logger) (DB, error) {
 
 	ctx := context.Background()
 	clientOpts := options.Client().ApplyURI(config.MongoSource)
-	if !config.IsProduction() {
-		cmdMonitor := &event.CommandMonitor{
-			Started: func(ctx context.Context, event *event.CommandStartedEvent) {
-				var query any
-				json.Unmarshal([]byte(event.Command.String()), &query)
-				subLogger := port.GetCtxSubLogger(ctx, logger)
-				subLogger.Info().Field("query", query).Msgf("mongo %s", event.CommandName)
-			},
-		}
-		clientOpts.SetMonitor(cmdMonitor)
-	}
+	clientOpts.SetMonitor(newCommandMonitor(config, logger))
 	client, err := mongo.Connect(ctx, clientOpts)
 	if err != nil {
 		return DB{}, err
@@ -65,6 +62,15 @@ func (db *DB) Collection(name string) *mongo.Collection {
 	return db.Client().Database(DBName).Collection(name)
 }
 
+// Context bind operation to transaction session,
+// callback of atomic use caller context that has no session
+func (db *DB) Context(ctx context.Context) context.Context {
+	if db.session == nil {
+		return ctx
+	}
+	return mongo.NewSessionContext(ctx, db.session)
+}
+
 func (db *DB) migrate() error {
 	ctx := context.Background()
 
@@ -86,6 +92,107 @@ func (db *DB) migrate() error {
 		return err
 	}
 
+	// article index
+	_, err = db.Collection(CollectionArticle).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "id", Value: -1}}},
+		{Keys: bson.D{{Key: "updated_at", Value: -1}, {Key: "id", Value: -1}}},
+		{Keys: bson.D{{Key: "favorites_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "id", Value: -1}}},
+		{Keys: bson.D{{Key: "comments_count", Value: -1}, {Key: "created_at", Value: -1}, {Key: "id", Value: -1}}},
+	})
+	if err != nil {
+		return err
+	}
+	if err := db.backfillArticleCount(ctx); err != nil {
+		return err
+	}
+
+	// comment index
+	_, err = db.Collection(CollectionComment).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "article_id", Value: 1}, {Key: "created_at", Value: 1}, {Key: "id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection(CollectionComment).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "parent_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection(CollectionCommentRevision).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "comment_id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// mention index
+	_, err = db.Collection(CollectionMention).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection(CollectionMention).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "article_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// notification index
+	_, err = db.Collection(CollectionNotification).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// webhook index
+	_, err = db.Collection(CollectionWebhook).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection(CollectionWebhookDelivery).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "webhook_id", Value: 1}, {Key: "created_at", Value: -1}},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection(CollectionWebhookDelivery).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "webhook_id", Value: 1}, {Key: "event_id", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"event_id": bson.M{"$exists": true}}),
+	})
+	if err != nil {
+		return err
+	}
+
+	// outbox index
+	_, err = db.Collection(CollectionOutboxEvent).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "dedupe_key", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = db.Collection(CollectionOutboxEvent).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "available_at", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// idempotency index
+	_, err = db.Collection(CollectionIdempotency).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expired_at", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
 	// tag index
 	_, err = db.Collection(CollectionTag).Indexes().CreateOne(ctx, mongo.IndexModel{
 		Keys:    bson.D{{Key: "name", Value: 1}},
@@ -115,3 +222,34 @@ func (db *DB) migrate() error {
 
 	return nil
 }
+
+// backfillArticleCount set denormalized counters of articles created before they exist
+func (db *DB) backfillArticleCount(ctx context.Context) error {
+	cursor, err := db.Collection(CollectionArticle).Find(ctx, bson.M{"favorites_count": bson.M{"$exists": false}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		article := model.Article{}
+		if err := cursor.Decode(&article); err != nil {
+			return err
+		}
+		favorites, err := db.Collection(CollectionArticleFavorite).CountDocuments(ctx, bson.M{"article_id": article.ID})
+		if err != nil {
+			return err
+		}
+		comments, err := db.Collection(CollectionComment).CountDocuments(ctx, bson.M{"article_id": article.ID})
+		if err != nil {
+			return err
+		}
+		_, err = db.Collection(CollectionArticle).UpdateOne(ctx, bson.M{"id": article.ID}, bson.M{
+			"$set": bson.M{"favorites_count": favorites, "comments_count": comments},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
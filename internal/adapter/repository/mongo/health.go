@@ -0,0 +1,17 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// Health ping primary, indexes are ensured on connect so there is no migration version
+func (r *mongoRepo) Health(ctx context.Context) (port.RepositoryHealth, error) {
+	health := port.RepositoryHealth{Database: TypeMongo}
+	if err := r.db.Client().Ping(ctx, readpref.Primary()); err != nil {
+		return health, intoException(err)
+	}
+	return health, nil
+}
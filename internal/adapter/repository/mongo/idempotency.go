@@ -0,0 +1,106 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/mongo/model"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type idempotencyRepo struct {
+	db DB
+}
+
+func NewIdempotencyRepository(db DB) port.IdempotencyRepository {
+	return &idempotencyRepo{
+		db: db,
+	}
+}
+
+func (r *idempotencyRepo) AddIdempotency(ctx context.Context, arg domain.Idempotency) (bool, error) {
+	ctx = r.db.Context(ctx)
+	_, err := r.db.Collection(CollectionIdempotency).InsertOne(ctx, model.AsIdempotency(arg))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, intoException(err)
+	}
+	return true, nil
+}
+
+func (r *idempotencyRepo) FilterIdempotency(ctx context.Context, arg port.FilterIdempotencyPayload) ([]domain.Idempotency, error) {
+	ctx = r.db.Context(ctx)
+	cursor, err := r.db.Collection(CollectionIdempotency).Find(ctx, r.filter(arg))
+	if err != nil {
+		return []domain.Idempotency{}, intoException(err)
+	}
+
+	result := []domain.Idempotency{}
+	for cursor.Next(ctx) {
+		data := model.Idempotency{}
+		if err := cursor.Decode(&data); err != nil {
+			return []domain.Idempotency{}, intoException(err)
+		}
+		result = append(result, data.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *idempotencyRepo) UpdateIdempotency(ctx context.Context, arg domain.Idempotency) (domain.Idempotency, error) {
+	ctx = r.db.Context(ctx)
+	fields := bson.M{
+		"status":        arg.Status,
+		"response_code": arg.ResponseCode,
+		"response":      arg.Response,
+		"expired_at":    arg.ExpiredAt.UTC(),
+	}
+	_, err := r.db.Collection(CollectionIdempotency).UpdateOne(ctx, bson.M{"id": arg.ID}, bson.M{"$set": fields})
+	if err != nil {
+		return domain.Idempotency{}, intoException(err)
+	}
+
+	// find updated
+	idempotencies, err := r.FilterIdempotency(ctx, port.FilterIdempotencyPayload{IDs: []domain.ID{arg.ID}})
+	if err != nil {
+		return domain.Idempotency{}, intoException(err)
+	}
+	if len(idempotencies) == 0 {
+		return domain.Idempotency{}, exception.New(exception.TypeNotFound, "idempotency key not found", nil)
+	}
+	return idempotencies[0], nil
+}
+
+func (r *idempotencyRepo) DeleteIdempotency(ctx context.Context, arg port.FilterIdempotencyPayload) (int, error) {
+	ctx = r.db.Context(ctx)
+	result, err := r.db.Collection(CollectionIdempotency).DeleteMany(ctx, r.filter(arg))
+	if err != nil {
+		return 0, intoException(err)
+	}
+	return int(result.DeletedCount), nil
+}
+
+func (r *idempotencyRepo) filter(arg port.FilterIdempotencyPayload) bson.M {
+	query := []bson.M{}
+	if len(arg.IDs) > 0 {
+		query = append(query, bson.M{"id": bson.M{"$in": arg.IDs}})
+	}
+	if len(arg.UserIDs) > 0 {
+		query = append(query, bson.M{"user_id": bson.M{"$in": arg.UserIDs}})
+	}
+	if len(arg.Keys) > 0 {
+		query = append(query, bson.M{"key": bson.M{"$in": arg.Keys}})
+	}
+	if !arg.ExpiredBefore.IsZero() {
+		query = append(query, bson.M{"expired_at": bson.M{"$lte": arg.ExpiredBefore.UTC()}})
+	}
+	filter := bson.M{}
+	if len(query) > 0 {
+		filter = bson.M{"$and": query}
+	}
+	return filter
+}
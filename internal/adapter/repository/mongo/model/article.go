@@ -15,6 +15,12 @@ type Article struct {
 	Body        string    `bson:"body"`
 	CreatedAt   time.Time `bson:"created_at"`
 	UpdatedAt   time.Time `bson:"updated_at"`
+	Version     int       `bson:"version"`
+
+	// FavoritesCount and CommentsCount are denormalized to sort articles,
+	// maintained by repository along with favorite/comment change
+	FavoritesCount int `bson:"favorites_count"`
+	CommentsCount  int `bson:"comments_count"`
 }
 
 func (data Article) ToDomain() domain.Article {
@@ -27,6 +33,7 @@ func (data Article) ToDomain() domain.Article {
 		Body:        data.Body,
 		CreatedAt:   data.CreatedAt.UTC(),
 		UpdatedAt:   data.UpdatedAt.UTC(),
+		Version:     data.Version,
 	}
 }
 
@@ -40,6 +47,7 @@ func AsArticle(arg domain.Article) Article {
 		Body:        arg.Body,
 		CreatedAt:   arg.CreatedAt.UTC(),
 		UpdatedAt:   arg.UpdatedAt.UTC(),
+		Version:     arg.Version,
 	}
 }
 
@@ -85,7 +93,11 @@ type Comment struct {
 	ID        domain.ID `bson:"id"`
 	ArticleID domain.ID `bson:"article_id"`
 	AuthorID  domain.ID `bson:"author_id"`
+	ParentID  domain.ID `bson:"parent_id"`
+	Depth     int       `bson:"depth"`
 	Body      string    `bson:"body"`
+	IsDeleted bool      `bson:"is_deleted"`
+	IsEdited  bool      `bson:"is_edited"`
 	CreatedAt time.Time `bson:"created_at"`
 	UpdatedAt time.Time `bson:"updated_at"`
 }
@@ -95,7 +107,11 @@ func (data Comment) ToDomain() domain.Comment {
 		ID:        data.ID,
 		ArticleID: data.ArticleID,
 		AuthorID:  data.AuthorID,
+		ParentID:  data.ParentID,
+		Depth:     data.Depth,
 		Body:      data.Body,
+		IsDeleted: data.IsDeleted,
+		IsEdited:  data.IsEdited,
 		CreatedAt: data.CreatedAt.UTC(),
 		UpdatedAt: data.UpdatedAt.UTC(),
 	}
@@ -106,12 +122,72 @@ func AsComment(arg domain.Comment) Comment {
 		ID:        arg.ID,
 		ArticleID: arg.ArticleID,
 		AuthorID:  arg.AuthorID,
+		ParentID:  arg.ParentID,
+		Depth:     arg.Depth,
 		Body:      arg.Body,
+		IsDeleted: arg.IsDeleted,
+		IsEdited:  arg.IsEdited,
 		CreatedAt: arg.CreatedAt.UTC(),
 		UpdatedAt: arg.UpdatedAt.UTC(),
 	}
 }
 
+type CommentRevision struct {
+	ID        domain.ID `bson:"id"`
+	CommentID domain.ID `bson:"comment_id"`
+	Body      string    `bson:"body"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (data CommentRevision) ToDomain() domain.CommentRevision {
+	return domain.CommentRevision{
+		ID:        data.ID,
+		CommentID: data.CommentID,
+		Body:      data.Body,
+		CreatedAt: data.CreatedAt.UTC(),
+	}
+}
+
+func AsCommentRevision(arg domain.CommentRevision) CommentRevision {
+	return CommentRevision{
+		ID:        arg.ID,
+		CommentID: arg.CommentID,
+		Body:      arg.Body,
+		CreatedAt: arg.CreatedAt.UTC(),
+	}
+}
+
+type Mention struct {
+	ID        domain.ID `bson:"id"`
+	UserID    domain.ID `bson:"user_id"`
+	AuthorID  domain.ID `bson:"author_id"`
+	ArticleID domain.ID `bson:"article_id"`
+	CommentID domain.ID `bson:"comment_id"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (data Mention) ToDomain() domain.Mention {
+	return domain.Mention{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		AuthorID:  data.AuthorID,
+		ArticleID: data.ArticleID,
+		CommentID: data.CommentID,
+		CreatedAt: data.CreatedAt.UTC(),
+	}
+}
+
+func AsMention(arg domain.Mention) Mention {
+	return Mention{
+		ID:        arg.ID,
+		UserID:    arg.UserID,
+		AuthorID:  arg.AuthorID,
+		ArticleID: arg.ArticleID,
+		CommentID: arg.CommentID,
+		CreatedAt: arg.CreatedAt.UTC(),
+	}
+}
+
 type ArticleFavorite struct {
 	ArticleID domain.ID `bson:"article_id"`
 	UserID    domain.ID `bson:"user_id"`
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type Idempotency struct {
+	ID           domain.ID `bson:"id"`
+	UserID       domain.ID `bson:"user_id"`
+	Key          string    `bson:"key"`
+	RequestHash  string    `bson:"request_hash"`
+	Status       string    `bson:"status"`
+	ResponseCode int       `bson:"response_code"`
+	Response     []byte    `bson:"response,omitempty"`
+	CreatedAt    time.Time `bson:"created_at"`
+	ExpiredAt    time.Time `bson:"expired_at"`
+}
+
+func (data Idempotency) ToDomain() domain.Idempotency {
+	return domain.Idempotency{
+		ID:           data.ID,
+		UserID:       data.UserID,
+		Key:          data.Key,
+		RequestHash:  data.RequestHash,
+		Status:       data.Status,
+		ResponseCode: data.ResponseCode,
+		Response:     data.Response,
+		CreatedAt:    data.CreatedAt.UTC(),
+		ExpiredAt:    data.ExpiredAt.UTC(),
+	}
+}
+
+func AsIdempotency(arg domain.Idempotency) Idempotency {
+	return Idempotency{
+		ID:           arg.ID,
+		UserID:       arg.UserID,
+		Key:          arg.Key,
+		RequestHash:  arg.RequestHash,
+		Status:       arg.Status,
+		ResponseCode: arg.ResponseCode,
+		Response:     arg.Response,
+		CreatedAt:    arg.CreatedAt.UTC(),
+		ExpiredAt:    arg.ExpiredAt.UTC(),
+	}
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type Notification struct {
+	ID        domain.ID `bson:"id"`
+	UserID    domain.ID `bson:"user_id"`
+	ActorID   domain.ID `bson:"actor_id"`
+	Type      string    `bson:"type"`
+	ArticleID domain.ID `bson:"article_id"`
+	CommentID domain.ID `bson:"comment_id"`
+	ReadAt    time.Time `bson:"read_at,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (data Notification) ToDomain() domain.Notification {
+	return domain.Notification{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		ActorID:   data.ActorID,
+		Type:      data.Type,
+		ArticleID: data.ArticleID,
+		CommentID: data.CommentID,
+		ReadAt:    data.ReadAt.UTC(),
+		CreatedAt: data.CreatedAt.UTC(),
+	}
+}
+
+func AsNotification(arg domain.Notification) Notification {
+	return Notification{
+		ID:        arg.ID,
+		UserID:    arg.UserID,
+		ActorID:   arg.ActorID,
+		Type:      arg.Type,
+		ArticleID: arg.ArticleID,
+		CommentID: arg.CommentID,
+		ReadAt:    arg.ReadAt.UTC(),
+		CreatedAt: arg.CreatedAt.UTC(),
+	}
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type OutboxEvent struct {
+	ID          domain.ID `bson:"id"`
+	Type        string    `bson:"type"`
+	DedupeKey   string    `bson:"dedupe_key"`
+	UserID      domain.ID `bson:"user_id"`
+	Payload     string    `bson:"payload"`
+	Status      string    `bson:"status"`
+	Attempts    int       `bson:"attempts"`
+	Error       string    `bson:"error"`
+	AvailableAt time.Time `bson:"available_at"`
+	ProcessedAt time.Time `bson:"processed_at,omitempty"`
+	CreatedAt   time.Time `bson:"created_at"`
+}
+
+func (data OutboxEvent) ToDomain() domain.OutboxEvent {
+	return domain.OutboxEvent{
+		ID:          data.ID,
+		Type:        data.Type,
+		DedupeKey:   data.DedupeKey,
+		UserID:      data.UserID,
+		Payload:     data.Payload,
+		Status:      data.Status,
+		Attempts:    data.Attempts,
+		Error:       data.Error,
+		AvailableAt: data.AvailableAt.UTC(),
+		ProcessedAt: data.ProcessedAt.UTC(),
+		CreatedAt:   data.CreatedAt.UTC(),
+	}
+}
+
+func AsOutboxEvent(arg domain.OutboxEvent) OutboxEvent {
+	return OutboxEvent{
+		ID:          arg.ID,
+		Type:        arg.Type,
+		DedupeKey:   arg.DedupeKey,
+		UserID:      arg.UserID,
+		Payload:     arg.Payload,
+		Status:      arg.Status,
+		Attempts:    arg.Attempts,
+		Error:       arg.Error,
+		AvailableAt: arg.AvailableAt.UTC(),
+		ProcessedAt: arg.ProcessedAt.UTC(),
+		CreatedAt:   arg.CreatedAt.UTC(),
+	}
+}
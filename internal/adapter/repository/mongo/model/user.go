@@ -15,6 +15,7 @@ type User struct {
 	Bio       string    `bson:"bio"`
 	CreatedAt time.Time `bson:"created_at"`
 	UpdatedAt time.Time `bson:"updated_at"`
+	Version   int       `bson:"version"`
 }
 
 func (data User) ToDomain() domain.User {
@@ -27,6 +28,7 @@ func (data User) ToDomain() domain.User {
 		Bio:       data.Bio,
 		CreatedAt: data.CreatedAt,
 		UpdatedAt: data.UpdatedAt,
+		Version:   data.Version,
 	}
 }
 
@@ -40,18 +42,21 @@ func AsUser(arg domain.User) User {
 		Bio:       arg.Bio,
 		CreatedAt: arg.CreatedAt,
 		UpdatedAt: arg.UpdatedAt,
+		Version:   arg.Version,
 	}
 }
 
 type UserFollow struct {
 	FollowerID domain.ID `bson:"follower_id"`
 	FolloweeID domain.ID `bson:"followee_id"`
+	CreatedAt  time.Time `bson:"created_at"`
 }
 
 func (data UserFollow) ToDomain() domain.UserFollow {
 	return domain.UserFollow{
 		FollowerID: data.FollowerID,
 		FolloweeID: data.FolloweeID,
+		CreatedAt:  data.CreatedAt,
 	}
 }
 
@@ -59,5 +64,6 @@ func AsUserFollow(arg domain.UserFollow) UserFollow {
 	return UserFollow{
 		FollowerID: arg.FollowerID,
 		FolloweeID: arg.FolloweeID,
+		CreatedAt:  arg.CreatedAt,
 	}
 }
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type Webhook struct {
+	ID        domain.ID `bson:"id"`
+	UserID    domain.ID `bson:"user_id"`
+	URL       string    `bson:"url"`
+	Secret    string    `bson:"secret"`
+	Events    []string  `bson:"events"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+func (data Webhook) ToDomain() domain.Webhook {
+	return domain.Webhook{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		URL:       data.URL,
+		Secret:    data.Secret,
+		Events:    data.Events,
+		CreatedAt: data.CreatedAt.UTC(),
+		UpdatedAt: data.UpdatedAt.UTC(),
+	}
+}
+
+func AsWebhook(arg domain.Webhook) Webhook {
+	return Webhook{
+		ID:        arg.ID,
+		UserID:    arg.UserID,
+		URL:       arg.URL,
+		Secret:    arg.Secret,
+		Events:    arg.Events,
+		CreatedAt: arg.CreatedAt.UTC(),
+		UpdatedAt: arg.UpdatedAt.UTC(),
+	}
+}
+
+type WebhookDelivery struct {
+	ID           domain.ID `bson:"id"`
+	WebhookID    domain.ID `bson:"webhook_id"`
+	EventID      domain.ID `bson:"event_id,omitempty"`
+	Event        string    `bson:"event"`
+	Payload      string    `bson:"payload"`
+	Status       string    `bson:"status"`
+	Attempts     int       `bson:"attempts"`
+	ResponseCode int       `bson:"response_code"`
+	Error        string    `bson:"error"`
+	CreatedAt    time.Time `bson:"created_at"`
+	UpdatedAt    time.Time `bson:"updated_at"`
+}
+
+func (data WebhookDelivery) ToDomain() domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID:           data.ID,
+		WebhookID:    data.WebhookID,
+		EventID:      data.EventID,
+		Event:        data.Event,
+		Payload:      data.Payload,
+		Status:       data.Status,
+		Attempts:     data.Attempts,
+		ResponseCode: data.ResponseCode,
+		Error:        data.Error,
+		CreatedAt:    data.CreatedAt.UTC(),
+		UpdatedAt:    data.UpdatedAt.UTC(),
+	}
+}
+
+func AsWebhookDelivery(arg domain.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:           arg.ID,
+		WebhookID:    arg.WebhookID,
+		EventID:      arg.EventID,
+		Event:        arg.Event,
+		Payload:      arg.Payload,
+		Status:       arg.Status,
+		Attempts:     arg.Attempts,
+		ResponseCode: arg.ResponseCode,
+		Error:        arg.Error,
+		CreatedAt:    arg.CreatedAt.UTC(),
+		UpdatedAt:    arg.UpdatedAt.UTC(),
+	}
+}
@@ -0,0 +1,75 @@
+package mongo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/metrics"
+	"github.com/labasubagia/realworld-backend/internal/core/util/tracing"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// commandMonitor trace and observe every command, log command outside production
+type commandMonitor struct {
+	verbose bool
+	logger  port.Logger
+	spans   sync.Map // span of running command by connection and request id
+}
+
+func newCommandMonitor(config util.Config, logger port.Logger) *event.CommandMonitor {
+	monitor := &commandMonitor{
+		verbose: !config.IsProduction(),
+		logger:  logger,
+	}
+	return &event.CommandMonitor{
+		Started:   monitor.started,
+		Succeeded: monitor.succeeded,
+		Failed:    monitor.failed,
+	}
+}
+
+func (m *commandMonitor) started(ctx context.Context, event *event.CommandStartedEvent) {
+	attrs := []attribute.KeyValue{
+		attribute.String("db.system", "mongodb"),
+		attribute.String("db.operation", event.CommandName),
+		attribute.String("db.name", event.DatabaseName),
+	}
+	if m.verbose {
+		attrs = append(attrs, attribute.String("db.statement", event.Command.String()))
+	}
+	_, span := tracing.Start(ctx, "mongo "+event.CommandName, attrs...)
+	m.spans.Store(m.key(event.ConnectionID, event.RequestID), span)
+
+	if m.verbose {
+		var query any
+		json.Unmarshal([]byte(event.Command.String()), &query)
+		subLogger := port.GetCtxSubLogger(ctx, m.logger)
+		subLogger.Info().Field("query", query).Msgf("mongo %s", event.CommandName)
+	}
+}
+
+func (m *commandMonitor) succeeded(ctx context.Context, event *event.CommandSucceededEvent) {
+	metrics.ObserveQuery(TypeMongo, event.CommandName, true, time.Duration(event.DurationNanos))
+	if span, ok := m.spans.LoadAndDelete(m.key(event.ConnectionID, event.RequestID)); ok {
+		span.(trace.Span).End()
+	}
+}
+
+func (m *commandMonitor) failed(ctx context.Context, event *event.CommandFailedEvent) {
+	metrics.ObserveQuery(TypeMongo, event.CommandName, false, time.Duration(event.DurationNanos))
+	if span, ok := m.spans.LoadAndDelete(m.key(event.ConnectionID, event.RequestID)); ok {
+		tracing.End(span.(trace.Span), errors.New(event.Failure))
+	}
+}
+
+func (m *commandMonitor) key(connectionID string, requestID int64) string {
+	return fmt.Sprintf("%s/%d", connectionID, requestID)
+}
@@ -0,0 +1,109 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/mongo/model"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type notificationRepo struct {
+	db DB
+}
+
+func NewNotificationRepository(db DB) port.NotificationRepository {
+	return &notificationRepo{
+		db: db,
+	}
+}
+
+func (r *notificationRepo) AddNotifications(ctx context.Context, arg []domain.Notification) ([]domain.Notification, error) {
+	ctx = r.db.Context(ctx)
+	if len(arg) == 0 {
+		return []domain.Notification{}, nil
+	}
+	notifications := make([]any, len(arg))
+	for i, notification := range arg {
+		notifications[i] = model.AsNotification(notification)
+	}
+	_, err := r.db.Collection(CollectionNotification).InsertMany(ctx, notifications)
+	if err != nil {
+		return []domain.Notification{}, intoException(err)
+	}
+	result := []domain.Notification{}
+	for _, notification := range notifications {
+		notification, ok := notification.(model.Notification)
+		if !ok {
+			continue
+		}
+		result = append(result, notification.ToDomain())
+	}
+	return result, nil
+}
+
+// notificationQuery make filter shared by FilterNotification and CountNotification
+func notificationQuery(arg port.FilterNotificationPayload) bson.M {
+	query := []bson.M{}
+	if len(arg.IDs) > 0 {
+		query = append(query, bson.M{"id": bson.M{"$in": arg.IDs}})
+	}
+	if len(arg.UserIDs) > 0 {
+		query = append(query, bson.M{"user_id": bson.M{"$in": arg.UserIDs}})
+	}
+	if arg.UnreadOnly {
+		// null match missing read_at
+		query = append(query, bson.M{"read_at": nil})
+	}
+	if len(query) == 0 {
+		return bson.M{}
+	}
+	return bson.M{"$and": query}
+}
+
+func (r *notificationRepo) FilterNotification(ctx context.Context, arg port.FilterNotificationPayload) ([]domain.Notification, error) {
+	ctx = r.db.Context(ctx)
+	limit := int64(arg.Limit)
+	offset := int64(arg.Offset)
+	option := options.FindOptions{Limit: &limit, Skip: &offset, Sort: bson.D{{Key: "created_at", Value: -1}, {Key: "id", Value: -1}}}
+
+	cursor, err := r.db.Collection(CollectionNotification).Find(ctx, notificationQuery(arg), &option)
+	if err != nil {
+		return []domain.Notification{}, intoException(err)
+	}
+
+	result := []domain.Notification{}
+	for cursor.Next(ctx) {
+		data := model.Notification{}
+		if err := cursor.Decode(&data); err != nil {
+			return []domain.Notification{}, intoException(err)
+		}
+		result = append(result, data.ToDomain())
+	}
+
+	return result, nil
+}
+
+func (r *notificationRepo) CountNotification(ctx context.Context, arg port.FilterNotificationPayload) (int, error) {
+	ctx = r.db.Context(ctx)
+	count, err := r.db.Collection(CollectionNotification).CountDocuments(ctx, notificationQuery(arg))
+	if err != nil {
+		return 0, intoException(err)
+	}
+	return int(count), nil
+}
+
+func (r *notificationRepo) MarkNotificationRead(ctx context.Context, arg port.MarkNotificationReadPayload) error {
+	ctx = r.db.Context(ctx)
+	filter := bson.M{"user_id": arg.UserID, "read_at": nil}
+	if len(arg.IDs) > 0 {
+		filter["id"] = bson.M{"$in": arg.IDs}
+	}
+	_, err := r.db.Collection(CollectionNotification).UpdateMany(ctx, filter, bson.M{"$set": bson.M{"read_at": arg.ReadAt.UTC()}})
+	if err != nil {
+		return intoException(err)
+	}
+	return nil
+}
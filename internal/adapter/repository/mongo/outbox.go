@@ -0,0 +1,155 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/mongo/model"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type outboxRepo struct {
+	db DB
+}
+
+func NewOutboxRepository(db DB) port.OutboxRepository {
+	return &outboxRepo{
+		db: db,
+	}
+}
+
+// AddOutboxEvents is atomic with the change when called inside transaction of Atomic
+func (r *outboxRepo) AddOutboxEvents(ctx context.Context, arg []domain.OutboxEvent) error {
+	ctx = r.db.Context(ctx)
+	if len(arg) == 0 {
+		return nil
+	}
+
+	// skip stored dedupe key first, duplicate key error abort transaction
+	dedupeKeys := make([]string, len(arg))
+	for i, event := range arg {
+		dedupeKeys[i] = event.DedupeKey
+	}
+	existing, err := r.FilterOutboxEvent(ctx, port.FilterOutboxPayload{DedupeKeys: dedupeKeys})
+	if err != nil {
+		return intoException(err)
+	}
+	stored := map[string]bool{}
+	for _, event := range existing {
+		stored[event.DedupeKey] = true
+	}
+	events := []any{}
+	for _, event := range arg {
+		if stored[event.DedupeKey] {
+			continue
+		}
+		stored[event.DedupeKey] = true
+		events = append(events, model.AsOutboxEvent(event))
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	// unordered insert continue after duplicate dedupe key of concurrent writer
+	_, err = r.db.Collection(CollectionOutboxEvent).InsertMany(ctx, events, options.InsertMany().SetOrdered(false))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return intoException(err)
+	}
+	return nil
+}
+
+func (r *outboxRepo) FilterOutboxEvent(ctx context.Context, arg port.FilterOutboxPayload) ([]domain.OutboxEvent, error) {
+	ctx = r.db.Context(ctx)
+	query := []bson.M{}
+	if len(arg.IDs) > 0 {
+		query = append(query, bson.M{"id": bson.M{"$in": arg.IDs}})
+	}
+	if len(arg.DedupeKeys) > 0 {
+		query = append(query, bson.M{"dedupe_key": bson.M{"$in": arg.DedupeKeys}})
+	}
+	if len(arg.Statuses) > 0 {
+		query = append(query, bson.M{"status": bson.M{"$in": arg.Statuses}})
+	}
+	if !arg.AvailableBefore.IsZero() {
+		query = append(query, bson.M{"available_at": bson.M{"$lte": arg.AvailableBefore.UTC()}})
+	}
+	filter := bson.M{}
+	if len(query) > 0 {
+		filter = bson.M{"$and": query}
+	}
+
+	limit := int64(arg.Limit)
+	option := options.FindOptions{Limit: &limit, Sort: bson.D{{Key: "created_at", Value: 1}, {Key: "id", Value: 1}}}
+	cursor, err := r.db.Collection(CollectionOutboxEvent).Find(ctx, filter, &option)
+	if err != nil {
+		return []domain.OutboxEvent{}, intoException(err)
+	}
+
+	result := []domain.OutboxEvent{}
+	for cursor.Next(ctx) {
+		data := model.OutboxEvent{}
+		if err := cursor.Decode(&data); err != nil {
+			return []domain.OutboxEvent{}, intoException(err)
+		}
+		result = append(result, data.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *outboxRepo) ClaimOutboxEvents(ctx context.Context, arg port.ClaimOutboxPayload) ([]domain.OutboxEvent, error) {
+	ctx = r.db.Context(ctx)
+	filter := bson.M{
+		"status":       domain.OutboxStatusPending,
+		"available_at": bson.M{"$lte": arg.AvailableBefore.UTC()},
+	}
+	update := bson.M{"$set": bson.M{"available_at": arg.LeaseUntil.UTC()}}
+	option := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "id", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	// claim one by one, find and update of single document is atomic
+	result := []domain.OutboxEvent{}
+	for len(result) < arg.Limit {
+		data := model.OutboxEvent{}
+		err := r.db.Collection(CollectionOutboxEvent).FindOneAndUpdate(ctx, filter, update, option).Decode(&data)
+		if err == mongo.ErrNoDocuments {
+			break
+		}
+		if err != nil {
+			return []domain.OutboxEvent{}, intoException(err)
+		}
+		result = append(result, data.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *outboxRepo) UpdateOutboxEvent(ctx context.Context, arg domain.OutboxEvent) (domain.OutboxEvent, error) {
+	ctx = r.db.Context(ctx)
+	fields := bson.M{
+		"status":       arg.Status,
+		"attempts":     arg.Attempts,
+		"error":        arg.Error,
+		"available_at": arg.AvailableAt.UTC(),
+	}
+	if !arg.ProcessedAt.IsZero() {
+		fields["processed_at"] = arg.ProcessedAt.UTC()
+	}
+	_, err := r.db.Collection(CollectionOutboxEvent).UpdateOne(ctx, bson.M{"id": arg.ID}, bson.M{"$set": fields})
+	if err != nil {
+		return domain.OutboxEvent{}, intoException(err)
+	}
+
+	// find updated
+	events, err := r.FilterOutboxEvent(ctx, port.FilterOutboxPayload{IDs: []domain.ID{arg.ID}})
+	if err != nil {
+		return domain.OutboxEvent{}, intoException(err)
+	}
+	if len(events) == 0 {
+		return domain.OutboxEvent{}, exception.New(exception.TypeNotFound, "outbox event not found", nil)
+	}
+	return events[0], nil
+}
@@ -17,6 +17,10 @@ type mongoRepo struct {
 	logger      port.Logger
 	userRepo    port.UserRepository
 	articleRepo port.ArticleRepository
+	notifRepo   port.NotificationRepository
+	webhookRepo port.WebhookRepository
+	outboxRepo  port.OutboxRepository
+	idemRepo    port.IdempotencyRepository
 }
 
 func NewMongoRepository(config util.Config, logger port.Logger) (port.Repository, error) {
@@ -30,8 +34,13 @@ func NewMongoRepository(config util.Config, logger port.Logger) (port.Repository
 func create(db DB, logger port.Logger) port.Repository {
 	return &mongoRepo{
 		db:          db,
+		logger:      logger,
 		userRepo:    NewUserRepository(db),
 		articleRepo: NewArticleRepository(db),
+		notifRepo:   NewNotificationRepository(db),
+		webhookRepo: NewWebhookRepository(db),
+		outboxRepo:  NewOutboxRepository(db),
+		idemRepo:    NewIdempotencyRepository(db),
 	}
 }
 
@@ -45,8 +54,11 @@ func (r *mongoRepo) Atomic(ctx context.Context, fn port.RepositoryAtomicCallback
 	}
 	defer session.EndSession(ctx)
 
+	// transaction need replica set, standalone server reject it
 	_, err = session.WithTransaction(ctx, func(sessionCtx mongo.SessionContext) (any, error) {
-		if err := fn(create(r.db, r.logger)); err != nil {
+		db := r.db
+		db.session = sessionCtx
+		if err := fn(create(db, r.logger)); err != nil {
 			return nil, intoException(err)
 		}
 		return nil, nil
@@ -59,6 +71,14 @@ func (r *mongoRepo) Atomic(ctx context.Context, fn port.RepositoryAtomicCallback
 	return nil
 }
 
+// Close disconnect client, wait in use connection until ctx done
+func (r *mongoRepo) Close(ctx context.Context) error {
+	if err := r.db.Client().Disconnect(ctx); err != nil {
+		return intoException(err)
+	}
+	return nil
+}
+
 func (r *mongoRepo) User() port.UserRepository {
 	return r.userRepo
 }
@@ -66,3 +86,19 @@ func (r *mongoRepo) User() port.UserRepository {
 func (r *mongoRepo) Article() port.ArticleRepository {
 	return r.articleRepo
 }
+
+func (r *mongoRepo) Notification() port.NotificationRepository {
+	return r.notifRepo
+}
+
+func (r *mongoRepo) Webhook() port.WebhookRepository {
+	return r.webhookRepo
+}
+
+func (r *mongoRepo) Outbox() port.OutboxRepository {
+	return r.outboxRepo
+}
+
+func (r *mongoRepo) Idempotency() port.IdempotencyRepository {
+	return r.idemRepo
+}
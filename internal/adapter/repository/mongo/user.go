@@ -21,6 +21,7 @@ func NewUserRepository(db DB) port.UserRepository {
 	}
 }
 func (r *userRepo) CreateUser(ctx context.Context, arg domain.User) (domain.User, error) {
+	ctx = r.db.Context(ctx)
 	user := model.AsUser(arg)
 	_, err := r.db.Collection(CollectionUser).InsertOne(ctx, user)
 	if err != nil {
@@ -30,6 +31,7 @@ func (r *userRepo) CreateUser(ctx context.Context, arg domain.User) (domain.User
 }
 
 func (r *userRepo) FilterFollow(ctx context.Context, arg port.FilterUserFollowPayload) ([]domain.UserFollow, error) {
+	ctx = r.db.Context(ctx)
 	query := []bson.M{}
 	if len(arg.FollowerIDs) > 0 {
 		query = append(query, bson.M{"follower_id": bson.M{"$in": arg.FollowerIDs}})
@@ -60,6 +62,7 @@ func (r *userRepo) FilterFollow(ctx context.Context, arg port.FilterUserFollowPa
 }
 
 func (r *userRepo) FilterUser(ctx context.Context, arg port.FilterUserPayload) ([]domain.User, error) {
+	ctx = r.db.Context(ctx)
 
 	query := []bson.M{}
 	if len(arg.IDs) > 0 {
@@ -95,6 +98,7 @@ func (r *userRepo) FilterUser(ctx context.Context, arg port.FilterUserPayload) (
 }
 
 func (r *userRepo) FindOne(ctx context.Context, arg port.FilterUserPayload) (domain.User, error) {
+	ctx = r.db.Context(ctx)
 	users, err := r.FilterUser(ctx, arg)
 	if err != nil {
 		return domain.User{}, intoException(err)
@@ -106,6 +110,7 @@ func (r *userRepo) FindOne(ctx context.Context, arg port.FilterUserPayload) (dom
 }
 
 func (r *userRepo) Follow(ctx context.Context, arg domain.UserFollow) (domain.UserFollow, error) {
+	ctx = r.db.Context(ctx)
 	follow := model.AsUserFollow(arg)
 	_, err := r.db.Collection(CollectionUserFollow).InsertOne(ctx, follow)
 	if err != nil {
@@ -115,6 +120,7 @@ func (r *userRepo) Follow(ctx context.Context, arg domain.UserFollow) (domain.Us
 }
 
 func (r *userRepo) UnFollow(ctx context.Context, arg domain.UserFollow) (domain.UserFollow, error) {
+	ctx = r.db.Context(ctx)
 	_, err := r.db.Collection(CollectionUserFollow).DeleteOne(ctx, bson.M{
 		"follower_id": arg.FollowerID,
 		"followee_id": arg.FolloweeID,
@@ -126,6 +132,7 @@ func (r *userRepo) UnFollow(ctx context.Context, arg domain.UserFollow) (domain.
 }
 
 func (r *userRepo) UpdateUser(ctx context.Context, arg domain.User) (domain.User, error) {
+	ctx = r.db.Context(ctx)
 
 	filter := bson.M{"id": arg.ID}
 	fields := bson.M{}
@@ -148,10 +155,24 @@ func (r *userRepo) UpdateUser(ctx context.Context, arg domain.User) (domain.User
 		fields["updated_at"] = time.Now()
 	}
 
-	_, err := r.db.Collection(CollectionUser).UpdateOne(ctx, filter, bson.M{"$set": fields})
+	// only expected version when provided
+	if arg.Version > 0 {
+		filter["version"] = arg.Version
+	}
+	update := bson.M{"$inc": bson.M{"version": 1}}
+	if len(fields) > 0 {
+		update["$set"] = fields
+	}
+	res, err := r.db.Collection(CollectionUser).UpdateOne(ctx, filter, update)
 	if err != nil {
 		return domain.User{}, intoException(err)
 	}
+	if res.MatchedCount == 0 {
+		if _, err := r.FindOne(ctx, port.FilterUserPayload{IDs: []domain.ID{arg.ID}}); err != nil {
+			return domain.User{}, intoException(err)
+		}
+		return domain.User{}, exception.New(exception.TypeConflict, "user has been modified", nil)
+	}
 
 	updated, err := r.FindOne(ctx, port.FilterUserPayload{IDs: []domain.ID{arg.ID}})
 	if err != nil {
@@ -0,0 +1,150 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/mongo/model"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type webhookRepo struct {
+	db DB
+}
+
+func NewWebhookRepository(db DB) port.WebhookRepository {
+	return &webhookRepo{
+		db: db,
+	}
+}
+
+func (r *webhookRepo) CreateWebhook(ctx context.Context, arg domain.Webhook) (domain.Webhook, error) {
+	ctx = r.db.Context(ctx)
+	webhook := model.AsWebhook(arg)
+	_, err := r.db.Collection(CollectionWebhook).InsertOne(ctx, webhook)
+	if err != nil {
+		return domain.Webhook{}, intoException(err)
+	}
+	return webhook.ToDomain(), nil
+}
+
+func (r *webhookRepo) FilterWebhook(ctx context.Context, arg port.FilterWebhookPayload) ([]domain.Webhook, error) {
+	ctx = r.db.Context(ctx)
+	query := []bson.M{}
+	if len(arg.IDs) > 0 {
+		query = append(query, bson.M{"id": bson.M{"$in": arg.IDs}})
+	}
+	if len(arg.UserIDs) > 0 {
+		query = append(query, bson.M{"user_id": bson.M{"$in": arg.UserIDs}})
+	}
+	if len(arg.Events) > 0 {
+		query = append(query, bson.M{"events": bson.M{"$in": arg.Events}})
+	}
+	filter := bson.M{}
+	if len(query) > 0 {
+		filter = bson.M{"$and": query}
+	}
+
+	option := options.FindOptions{Sort: bson.D{{Key: "created_at", Value: -1}, {Key: "id", Value: -1}}}
+	cursor, err := r.db.Collection(CollectionWebhook).Find(ctx, filter, &option)
+	if err != nil {
+		return []domain.Webhook{}, intoException(err)
+	}
+
+	result := []domain.Webhook{}
+	for cursor.Next(ctx) {
+		data := model.Webhook{}
+		if err := cursor.Decode(&data); err != nil {
+			return []domain.Webhook{}, intoException(err)
+		}
+		result = append(result, data.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *webhookRepo) DeleteWebhook(ctx context.Context, arg domain.Webhook) error {
+	ctx = r.db.Context(ctx)
+	_, err := r.db.Collection(CollectionWebhook).DeleteOne(ctx, bson.M{"id": arg.ID})
+	if err != nil {
+		return intoException(err)
+	}
+	_, err = r.db.Collection(CollectionWebhookDelivery).DeleteMany(ctx, bson.M{"webhook_id": arg.ID})
+	if err != nil {
+		return intoException(err)
+	}
+	return nil
+}
+
+func (r *webhookRepo) AddWebhookDelivery(ctx context.Context, arg domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	ctx = r.db.Context(ctx)
+	delivery := model.AsWebhookDelivery(arg)
+	_, err := r.db.Collection(CollectionWebhookDelivery).InsertOne(ctx, delivery)
+	if err != nil {
+		return domain.WebhookDelivery{}, intoException(err)
+	}
+	return delivery.ToDomain(), nil
+}
+
+func (r *webhookRepo) UpdateWebhookDelivery(ctx context.Context, arg domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	ctx = r.db.Context(ctx)
+	fields := bson.M{
+		"status":        arg.Status,
+		"attempts":      arg.Attempts,
+		"response_code": arg.ResponseCode,
+		"error":         arg.Error,
+		"updated_at":    arg.UpdatedAt.UTC(),
+	}
+	_, err := r.db.Collection(CollectionWebhookDelivery).UpdateOne(ctx, bson.M{"id": arg.ID}, bson.M{"$set": fields})
+	if err != nil {
+		return domain.WebhookDelivery{}, intoException(err)
+	}
+
+	// find updated
+	deliveries, err := r.FilterWebhookDelivery(ctx, port.FilterWebhookDeliveryPayload{IDs: []domain.ID{arg.ID}})
+	if err != nil {
+		return domain.WebhookDelivery{}, intoException(err)
+	}
+	if len(deliveries) == 0 {
+		return domain.WebhookDelivery{}, exception.New(exception.TypeNotFound, "webhook delivery not found", nil)
+	}
+	return deliveries[0], nil
+}
+
+func (r *webhookRepo) FilterWebhookDelivery(ctx context.Context, arg port.FilterWebhookDeliveryPayload) ([]domain.WebhookDelivery, error) {
+	ctx = r.db.Context(ctx)
+	query := []bson.M{}
+	if len(arg.IDs) > 0 {
+		query = append(query, bson.M{"id": bson.M{"$in": arg.IDs}})
+	}
+	if len(arg.WebhookIDs) > 0 {
+		query = append(query, bson.M{"webhook_id": bson.M{"$in": arg.WebhookIDs}})
+	}
+	if len(arg.EventIDs) > 0 {
+		query = append(query, bson.M{"event_id": bson.M{"$in": arg.EventIDs}})
+	}
+	filter := bson.M{}
+	if len(query) > 0 {
+		filter = bson.M{"$and": query}
+	}
+
+	limit := int64(arg.Limit)
+	offset := int64(arg.Offset)
+	option := options.FindOptions{Limit: &limit, Skip: &offset, Sort: bson.D{{Key: "created_at", Value: -1}, {Key: "id", Value: -1}}}
+	cursor, err := r.db.Collection(CollectionWebhookDelivery).Find(ctx, filter, &option)
+	if err != nil {
+		return []domain.WebhookDelivery{}, intoException(err)
+	}
+
+	result := []domain.WebhookDelivery{}
+	for cursor.Next(ctx) {
+		data := model.WebhookDelivery{}
+		if err := cursor.Decode(&data); err != nil {
+			return []domain.WebhookDelivery{}, intoException(err)
+		}
+		result = append(result, data.ToDomain())
+	}
+	return result, nil
+}
@@ -34,12 +34,23 @@ func (r *articleRepo) UpdateArticle(ctx context.Context, arg domain.Article) (do
 		arg.SetTitle(arg.Title)
 	}
 	article := model.AsArticle(arg)
+	article.Version = 0 // omitted, incremented below
 
-	// update
-	_, err := r.db.NewUpdate().Model(&article).OmitZero().Where("id = ?", article.ID).Exec(ctx)
+	// update, only expected version when provided
+	query := r.db.NewUpdate().Model(&article).OmitZero().Value("version", "version + 1").Where("id = ?", article.ID)
+	if arg.Version > 0 {
+		query = query.Where("version = ?", arg.Version)
+	}
+	res, err := query.Exec(ctx)
 	if err != nil {
 		return domain.Article{}, intoException(err)
 	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		if _, err := r.FindOneArticle(ctx, port.FilterArticlePayload{IDs: []domain.ID{article.ID}}); err != nil {
+			return domain.Article{}, intoException(err)
+		}
+		return domain.Article{}, exception.New(exception.TypeConflict, "article has been modified", nil)
+	}
 
 	// find updated
 	updated, err := r.FindOneArticle(ctx, port.FilterArticlePayload{IDs: []domain.ID{article.ID}})
@@ -63,9 +74,8 @@ func (r *articleRepo) DeleteArticle(ctx context.Context, arg domain.Article) err
 	return nil
 }
 
-func (r *articleRepo) FilterArticle(ctx context.Context, filter port.FilterArticlePayload) ([]domain.Article, error) {
-	articles := []model.Article{}
-	query := r.db.NewSelect().Model(&articles)
+// whereArticle apply filter shared by FilterArticle and CountArticle
+func whereArticle(query *bun.SelectQuery, filter port.FilterArticlePayload) *bun.SelectQuery {
 	if len(filter.IDs) > 0 {
 		query = query.Where("id IN (?)", bun.In(filter.IDs))
 	}
@@ -75,11 +85,30 @@ func (r *articleRepo) FilterArticle(ctx context.Context, filter port.FilterArtic
 	if len(filter.AuthorIDs) > 0 {
 		query = query.Where("author_id IN (?)", bun.In(filter.AuthorIDs))
 	}
+	if !filter.CreatedAfter.IsZero() {
+		query = query.Where("created_at > ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedBefore)
+	}
+	return query
+}
+
+func (r *articleRepo) FilterArticle(ctx context.Context, filter port.FilterArticlePayload) ([]domain.Article, error) {
+	articles := []model.Article{}
+	query := whereArticle(r.db.NewSelect().Model(&articles), filter)
+	if !filter.Cursor.IsZero() {
+		if filter.Sort == port.ArticleSortOldest {
+			query = query.Where("(created_at, id) > (?, ?)", filter.Cursor.CreatedAt, filter.Cursor.ID)
+		} else {
+			query = query.Where("(created_at, id) < (?, ?)", filter.Cursor.CreatedAt, filter.Cursor.ID)
+		}
+	}
 	if filter.Limit > 0 {
 		query = query.Limit(filter.Limit)
 	}
 	query = query.Offset(filter.Offset)
-	query = query.Order("created_at DESC")
+	query = orderArticle(query, filter.Sort)
 	err := query.Scan(ctx)
 	if err != nil {
 		return []domain.Article{}, nil
@@ -91,6 +120,30 @@ func (r *articleRepo) FilterArticle(ctx context.Context, filter port.FilterArtic
 	return result, nil
 }
 
+// CountArticle count all articles matching filter, ignore pagination
+func (r *articleRepo) CountArticle(ctx context.Context, filter port.FilterArticlePayload) (int, error) {
+	count, err := whereArticle(r.db.NewSelect().Model((*model.Article)(nil)), filter).Count(ctx)
+	if err != nil {
+		return 0, intoException(err)
+	}
+	return count, nil
+}
+
+func orderArticle(query *bun.SelectQuery, sort string) *bun.SelectQuery {
+	switch sort {
+	case port.ArticleSortOldest:
+		return query.Order("created_at ASC", "id ASC")
+	case port.ArticleSortRecentlyUpdated:
+		return query.Order("updated_at DESC", "id DESC")
+	case port.ArticleSortMostFavorited:
+		return query.Order("favorites_count DESC", "created_at DESC", "id DESC")
+	case port.ArticleSortMostCommented:
+		return query.Order("comments_count DESC", "created_at DESC", "id DESC")
+	default:
+		return query.Order("created_at DESC", "id DESC")
+	}
+}
+
 func (r *articleRepo) FindOneArticle(ctx context.Context, filter port.FilterArticlePayload) (domain.Article, error) {
 	articles, err := r.FilterArticle(ctx, filter)
 	if err != nil {
@@ -214,12 +267,15 @@ func (r *articleRepo) AddFavorite(ctx context.Context, arg domain.ArticleFavorit
 	if err != nil {
 		return domain.ArticleFavorite{}, intoException(err)
 	}
+	if err := r.addArticleCount(ctx, favorite.ArticleID, "favorites_count", 1); err != nil {
+		return domain.ArticleFavorite{}, intoException(err)
+	}
 	return favorite.ToDomain(), nil
 }
 
 func (r *articleRepo) RemoveFavorite(ctx context.Context, arg domain.ArticleFavorite) (domain.ArticleFavorite, error) {
 	favorite := model.AsArticleFavorite(arg)
-	_, err := r.db.NewDelete().
+	res, err := r.db.NewDelete().
 		Model(&favorite).
 		Where("article_id = ?", favorite.ArticleID).
 		Where("user_id = ?", favorite.UserID).
@@ -227,9 +283,25 @@ func (r *articleRepo) RemoveFavorite(ctx context.Context, arg domain.ArticleFavo
 	if err != nil {
 		return domain.ArticleFavorite{}, intoException(err)
 	}
+	if affected, err := res.RowsAffected(); err == nil && affected > 0 {
+		if err := r.addArticleCount(ctx, favorite.ArticleID, "favorites_count", -1); err != nil {
+			return domain.ArticleFavorite{}, intoException(err)
+		}
+	}
 	return favorite.ToDomain(), nil
 }
 
+// addArticleCount change denormalized counter used to sort articles,
+// call within transaction of the counted change
+func (r *articleRepo) addArticleCount(ctx context.Context, articleID domain.ID, column string, delta int) error {
+	_, err := r.db.NewUpdate().
+		Model((*model.Article)(nil)).
+		Set("? = ? + ?", bun.Ident(column), bun.Ident(column), delta).
+		Where("id = ?", articleID).
+		Exec(ctx)
+	return err
+}
+
 func (r *articleRepo) FilterFavorite(ctx context.Context, arg port.FilterFavoritePayload) ([]domain.ArticleFavorite, error) {
 	articleFavorites := []model.ArticleFavorite{}
 	query := r.db.NewSelect().Model(&articleFavorites)
@@ -275,17 +347,48 @@ func (r *articleRepo) AddComment(ctx context.Context, arg domain.Comment) (domai
 	if err != nil {
 		return domain.Comment{}, intoException(err)
 	}
+	if err := r.addArticleCount(ctx, comment.ArticleID, "comments_count", 1); err != nil {
+		return domain.Comment{}, intoException(err)
+	}
 	return comment.ToDomain(), nil
 }
 
+// whereComment apply filter shared by FilterComment and CountComment
+func whereComment(query *bun.SelectQuery, filter port.FilterCommentPayload) *bun.SelectQuery {
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN (?)", bun.In(filter.IDs))
+	}
+	if len(filter.ArticleIDs) > 0 {
+		query = query.Where("article_id IN (?)", bun.In(filter.ArticleIDs))
+	}
+	if len(filter.AuthorIDs) > 0 {
+		query = query.Where("author_id IN (?)", bun.In(filter.AuthorIDs))
+	}
+	if len(filter.ParentIDs) > 0 {
+		query = query.Where("parent_id IN (?)", bun.In(filter.ParentIDs))
+	}
+	if filter.RootOnly {
+		query = query.Where("parent_id IS NULL")
+	}
+	return query
+}
+
 func (r *articleRepo) FilterComment(ctx context.Context, arg port.FilterCommentPayload) ([]domain.Comment, error) {
 	comments := []model.Comment{}
-	query := r.db.NewSelect().Model(&comments)
-	if len(arg.ArticleIDs) > 0 {
-		query = query.Where("article_id IN (?)", bun.In(arg.ArticleIDs))
+	query := whereComment(r.db.NewSelect().Model(&comments), arg)
+	if arg.Sort == port.CommentSortNewest {
+		if !arg.Cursor.IsZero() {
+			query = query.Where("(created_at, id) < (?, ?)", arg.Cursor.CreatedAt, arg.Cursor.ID)
+		}
+		query = query.Order("created_at DESC", "id DESC")
+	} else {
+		if !arg.Cursor.IsZero() {
+			query = query.Where("(created_at, id) > (?, ?)", arg.Cursor.CreatedAt, arg.Cursor.ID)
+		}
+		query = query.Order("created_at ASC", "id ASC")
 	}
-	if len(arg.AuthorIDs) > 0 {
-		query = query.Where("author_id IN (?)", bun.In(arg.AuthorIDs))
+	if arg.Limit > 0 {
+		query = query.Limit(arg.Limit)
 	}
 	err := query.Scan(ctx)
 	if err != nil {
@@ -298,14 +401,138 @@ func (r *articleRepo) FilterComment(ctx context.Context, arg port.FilterCommentP
 	return result, nil
 }
 
+// CountComment count all comments matching filter, ignore pagination
+func (r *articleRepo) CountComment(ctx context.Context, arg port.FilterCommentPayload) (int, error) {
+	count, err := whereComment(r.db.NewSelect().Model((*model.Comment)(nil)), arg).Count(ctx)
+	if err != nil {
+		return 0, intoException(err)
+	}
+	return count, nil
+}
+
+func (r *articleRepo) UpdateComment(ctx context.Context, arg domain.Comment) (domain.Comment, error) {
+	comment := model.AsComment(arg)
+	_, err := r.db.NewUpdate().
+		Model(&comment).
+		Column("body", "is_deleted", "is_edited", "updated_at").
+		Where("id = ?", comment.ID).
+		Exec(ctx)
+	if err != nil {
+		return domain.Comment{}, intoException(err)
+	}
+
+	comments, err := r.FilterComment(ctx, port.FilterCommentPayload{IDs: []domain.ID{comment.ID}})
+	if err != nil {
+		return domain.Comment{}, intoException(err)
+	}
+	if len(comments) == 0 {
+		return domain.Comment{}, exception.New(exception.TypeNotFound, "comment not found", nil)
+	}
+	return comments[0], nil
+}
+
 func (r *articleRepo) DeleteComment(ctx context.Context, arg domain.Comment) error {
 	comment := model.AsComment(arg)
-	_, err := r.db.NewDelete().
+	res, err := r.db.NewDelete().
 		Model(&comment).
 		Where("id = ?", comment.ID).
 		Where("author_id = ?", comment.AuthorID).
 		Where("article_id = ?", comment.ArticleID).
 		Exec(ctx)
+	// reply added after replies checked, comment is not removed with it
+	if postgresErrCode(err) == "23503" {
+		return exception.New(exception.TypeConflict, "comment has new replies", err)
+	}
+	if err != nil {
+		return intoException(err)
+	}
+	if affected, err := res.RowsAffected(); err == nil && affected > 0 {
+		if err := r.addArticleCount(ctx, comment.ArticleID, "comments_count", -1); err != nil {
+			return intoException(err)
+		}
+	}
+	return nil
+}
+
+func (r *articleRepo) AddCommentRevision(ctx context.Context, arg domain.CommentRevision) (domain.CommentRevision, error) {
+	revision := model.AsCommentRevision(arg)
+	_, err := r.db.NewInsert().Model(&revision).Exec(ctx)
+	if err != nil {
+		return domain.CommentRevision{}, intoException(err)
+	}
+	return revision.ToDomain(), nil
+}
+
+func (r *articleRepo) FilterCommentRevision(ctx context.Context, arg port.FilterCommentRevisionPayload) ([]domain.CommentRevision, error) {
+	revisions := []model.CommentRevision{}
+	query := r.db.NewSelect().Model(&revisions)
+	if len(arg.CommentIDs) > 0 {
+		query = query.Where("comment_id IN (?)", bun.In(arg.CommentIDs))
+	}
+	err := query.Order("created_at ASC", "id ASC").Scan(ctx)
+	if err != nil {
+		return []domain.CommentRevision{}, intoException(err)
+	}
+	result := []domain.CommentRevision{}
+	for _, revision := range revisions {
+		result = append(result, revision.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *articleRepo) AddMentions(ctx context.Context, arg []domain.Mention) ([]domain.Mention, error) {
+	if len(arg) == 0 {
+		return []domain.Mention{}, nil
+	}
+	mentions := make([]model.Mention, len(arg))
+	for i, mention := range arg {
+		mentions[i] = model.AsMention(mention)
+	}
+	_, err := r.db.NewInsert().Model(&mentions).Exec(ctx)
+	if err != nil {
+		return []domain.Mention{}, intoException(err)
+	}
+	result := []domain.Mention{}
+	for _, mention := range mentions {
+		result = append(result, mention.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *articleRepo) FilterMention(ctx context.Context, arg port.FilterMentionPayload) ([]domain.Mention, error) {
+	mentions := []model.Mention{}
+	query := r.db.NewSelect().Model(&mentions)
+	if len(arg.UserIDs) > 0 {
+		query = query.Where("user_id IN (?)", bun.In(arg.UserIDs))
+	}
+	if len(arg.ArticleIDs) > 0 {
+		query = query.Where("article_id IN (?)", bun.In(arg.ArticleIDs))
+	}
+	if arg.Limit > 0 {
+		query = query.Limit(arg.Limit)
+	}
+	if arg.Offset > 0 {
+		query = query.Offset(arg.Offset)
+	}
+	err := query.Order("created_at DESC", "id DESC").Scan(ctx)
+	if err != nil {
+		return []domain.Mention{}, intoException(err)
+	}
+	result := []domain.Mention{}
+	for _, mention := range mentions {
+		result = append(result, mention.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *articleRepo) DeleteMentions(ctx context.Context, ids []domain.ID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := r.db.NewDelete().
+		Model((*model.Mention)(nil)).
+		Where("id IN (?)", bun.In(ids)).
+		Exec(ctx)
 	if err != nil {
 		return intoException(err)
 	}
@@ -54,11 +54,9 @@ func (db *DB) connect() (*bun.DB, error) {
 	sqlDB := stdlib.OpenDB(*config)
 	database := bun.NewDB(sqlDB, pgdialect.New())
 
-	// log
-	if !db.config.IsProduction() {
-		// database.AddQueryHook(bundebug.NewQueryHook(bundebug.WithVerbose(true)))
-		database.AddQueryHook(&LoggerHook{verbose: true, logger: db.logger})
-	}
+	// log every query except production only log failed query, metrics always observed
+	// database.AddQueryHook(bundebug.NewQueryHook(bundebug.WithVerbose(true)))
+	database.AddQueryHook(&LoggerHook{verbose: !db.config.IsProduction(), logger: db.logger})
 	return database, nil
 }
 
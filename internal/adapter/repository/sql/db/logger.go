@@ -3,11 +3,16 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"strings"
 	"time"
 
 	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/metrics"
+	"github.com/labasubagia/realworld-backend/internal/core/util/tracing"
 	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type LoggerHook struct {
@@ -16,10 +21,28 @@ type LoggerHook struct {
 }
 
 func (h *LoggerHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	ctx, _ = tracing.Start(ctx, "SQL "+event.Operation(),
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation", event.Operation()),
+	)
 	return ctx
 }
 
 func (h *LoggerHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	success := event.Err == nil || errors.Is(event.Err, sql.ErrNoRows)
+	metrics.ObserveQuery("postgres", event.Operation(), success, time.Since(event.StartTime))
+
+	// statement has inlined arguments, only traced outside production
+	span := trace.SpanFromContext(ctx)
+	if h.verbose {
+		span.SetAttributes(attribute.String("db.statement", event.Query))
+	}
+	if success {
+		span.End()
+	} else {
+		tracing.End(span, event.Err)
+	}
+
 	if !h.verbose {
 		switch event.Err {
 		case nil, sql.ErrNoRows, sql.ErrTxDone:
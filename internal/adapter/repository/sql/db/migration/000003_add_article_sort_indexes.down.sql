@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS "comments_article_id_idx";
+
+--bun:split
+DROP INDEX IF EXISTS "article_favorites_article_id_idx";
+
+--bun:split
+DROP INDEX IF EXISTS "articles_updated_at_idx";
+
+--bun:split
+DROP INDEX IF EXISTS "articles_created_at_idx";
\ No newline at end of file
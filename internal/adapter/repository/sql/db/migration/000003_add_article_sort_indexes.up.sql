@@ -0,0 +1,10 @@
+CREATE INDEX "articles_created_at_idx" ON "articles" ("created_at");
+
+--bun:split
+CREATE INDEX "articles_updated_at_idx" ON "articles" ("updated_at");
+
+--bun:split
+CREATE INDEX "article_favorites_article_id_idx" ON "article_favorites" ("article_id");
+
+--bun:split
+CREATE INDEX "comments_article_id_idx" ON "comments" ("article_id");
\ No newline at end of file
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS "comments_parent_id_idx";
+
+--bun:split
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "is_deleted";
+
+--bun:split
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "depth";
+
+--bun:split
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "parent_id";
\ No newline at end of file
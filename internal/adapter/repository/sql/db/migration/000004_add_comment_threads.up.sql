@@ -0,0 +1,10 @@
+ALTER TABLE "comments" ADD COLUMN "parent_id" char(26) NULL REFERENCES "comments" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+--bun:split
+ALTER TABLE "comments" ADD COLUMN "depth" integer NOT NULL DEFAULT 0;
+
+--bun:split
+ALTER TABLE "comments" ADD COLUMN "is_deleted" boolean NOT NULL DEFAULT false;
+
+--bun:split
+CREATE INDEX "comments_parent_id_idx" ON "comments" ("parent_id");
\ No newline at end of file
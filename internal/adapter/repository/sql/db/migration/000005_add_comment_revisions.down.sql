@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "comment_revisions";
+
+--bun:split
+ALTER TABLE "comments" DROP COLUMN IF EXISTS "is_edited";
\ No newline at end of file
@@ -0,0 +1,13 @@
+ALTER TABLE "comments" ADD COLUMN "is_edited" boolean NOT NULL DEFAULT false;
+
+--bun:split
+CREATE TABLE "comment_revisions" (
+    "id" char(26) PRIMARY KEY,
+    "comment_id" char(26) NOT NULL,
+    "body" TEXT NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    FOREIGN KEY ("comment_id") REFERENCES "comments" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+--bun:split
+CREATE INDEX "comment_revisions_comment_id_idx" ON "comment_revisions" ("comment_id");
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "notifications";
+
+--bun:split
+DROP TABLE IF EXISTS "mentions";
\ No newline at end of file
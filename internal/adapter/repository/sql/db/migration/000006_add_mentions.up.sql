@@ -0,0 +1,36 @@
+CREATE TABLE "mentions" (
+    "id" char(26) PRIMARY KEY,
+    "user_id" char(26) NOT NULL,
+    "author_id" char(26) NOT NULL,
+    "article_id" char(26) NOT NULL,
+    "comment_id" char(26) NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY ("author_id") REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY ("article_id") REFERENCES "articles" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY ("comment_id") REFERENCES "comments" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+--bun:split
+CREATE INDEX "mentions_user_id_idx" ON "mentions" ("user_id", "created_at");
+
+--bun:split
+CREATE INDEX "mentions_article_id_idx" ON "mentions" ("article_id");
+
+--bun:split
+CREATE TABLE "notifications" (
+    "id" char(26) PRIMARY KEY,
+    "user_id" char(26) NOT NULL,
+    "actor_id" char(26) NOT NULL,
+    "type" varchar NOT NULL,
+    "article_id" char(26) NULL,
+    "comment_id" char(26) NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY ("actor_id") REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY ("article_id") REFERENCES "articles" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY ("comment_id") REFERENCES "comments" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+--bun:split
+CREATE INDEX "notifications_user_id_idx" ON "notifications" ("user_id", "created_at");
\ No newline at end of file
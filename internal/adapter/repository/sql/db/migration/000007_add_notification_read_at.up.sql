@@ -0,0 +1,4 @@
+ALTER TABLE "notifications" ADD COLUMN "read_at" timestamptz NULL;
+
+--bun:split
+CREATE INDEX "notifications_user_id_unread_idx" ON "notifications" ("user_id") WHERE "read_at" IS NULL;
\ No newline at end of file
@@ -0,0 +1,31 @@
+CREATE TABLE "webhooks" (
+    "id" char(26) PRIMARY KEY,
+    "user_id" char(26) NOT NULL,
+    "url" text NOT NULL,
+    "secret" varchar NOT NULL,
+    "events" varchar[] NOT NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+--bun:split
+CREATE INDEX "webhooks_user_id_idx" ON "webhooks" ("user_id");
+
+--bun:split
+CREATE TABLE "webhook_deliveries" (
+    "id" char(26) PRIMARY KEY,
+    "webhook_id" char(26) NOT NULL,
+    "event" varchar NOT NULL,
+    "payload" text NOT NULL,
+    "status" varchar NOT NULL,
+    "attempts" integer NOT NULL DEFAULT 0,
+    "response_code" integer NOT NULL DEFAULT 0,
+    "error" text NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "updated_at" timestamptz NOT NULL DEFAULT (now()),
+    FOREIGN KEY ("webhook_id") REFERENCES "webhooks" ("id") ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+--bun:split
+CREATE INDEX "webhook_deliveries_webhook_id_idx" ON "webhook_deliveries" ("webhook_id", "created_at");
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "webhook_deliveries_event_id_idx";
+
+--bun:split
+ALTER TABLE "webhook_deliveries" DROP COLUMN IF EXISTS "event_id";
+
+--bun:split
+DROP TABLE IF EXISTS "outbox_events";
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE "outbox_events" (
+    "id" char(26) PRIMARY KEY,
+    "type" varchar NOT NULL,
+    "dedupe_key" varchar NOT NULL,
+    "user_id" char(26) NULL,
+    "payload" text NOT NULL,
+    "status" varchar NOT NULL,
+    "attempts" integer NOT NULL DEFAULT 0,
+    "error" text NOT NULL DEFAULT '',
+    "available_at" timestamptz NOT NULL DEFAULT (now()),
+    "processed_at" timestamptz NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+--bun:split
+CREATE UNIQUE INDEX "outbox_events_dedupe_key_idx" ON "outbox_events" ("dedupe_key");
+
+--bun:split
+CREATE INDEX "outbox_events_status_idx" ON "outbox_events" ("status", "available_at");
+
+--bun:split
+ALTER TABLE "webhook_deliveries" ADD COLUMN "event_id" char(26) NULL;
+
+--bun:split
+CREATE UNIQUE INDEX "webhook_deliveries_event_id_idx" ON "webhook_deliveries" ("webhook_id", "event_id");
\ No newline at end of file
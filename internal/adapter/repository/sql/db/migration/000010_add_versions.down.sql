@@ -0,0 +1,4 @@
+ALTER TABLE "articles" DROP COLUMN IF EXISTS "version";
+
+--bun:split
+ALTER TABLE "users" DROP COLUMN IF EXISTS "version";
\ No newline at end of file
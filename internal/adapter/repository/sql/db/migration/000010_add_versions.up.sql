@@ -0,0 +1,4 @@
+ALTER TABLE "users" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
+
+--bun:split
+ALTER TABLE "articles" ADD COLUMN "version" integer NOT NULL DEFAULT 1;
\ No newline at end of file
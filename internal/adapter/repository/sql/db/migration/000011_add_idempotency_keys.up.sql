@@ -0,0 +1,17 @@
+CREATE TABLE "idempotency_keys" (
+    "id" char(26) PRIMARY KEY,
+    "user_id" char(26) NOT NULL,
+    "key" varchar NOT NULL,
+    "request_hash" varchar NOT NULL,
+    "status" varchar NOT NULL,
+    "response_code" integer NOT NULL DEFAULT 0,
+    "response" bytea NULL,
+    "created_at" timestamptz NOT NULL DEFAULT (now()),
+    "expired_at" timestamptz NOT NULL
+);
+
+--bun:split
+CREATE UNIQUE INDEX "idempotency_keys_user_id_key_idx" ON "idempotency_keys" ("user_id", "key");
+
+--bun:split
+CREATE INDEX "idempotency_keys_expired_at_idx" ON "idempotency_keys" ("expired_at");
\ No newline at end of file
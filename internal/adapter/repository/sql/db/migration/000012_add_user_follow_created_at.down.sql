@@ -0,0 +1 @@
+ALTER TABLE "user_follows" DROP COLUMN IF EXISTS "created_at";
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE "user_follows" ADD COLUMN "created_at" timestamptz NOT NULL DEFAULT (now());
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE "comments" DROP CONSTRAINT IF EXISTS "comments_parent_id_fkey";
+
+--bun:split
+ALTER TABLE "comments" ADD CONSTRAINT "comments_parent_id_fkey" FOREIGN KEY ("parent_id") REFERENCES "comments" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
\ No newline at end of file
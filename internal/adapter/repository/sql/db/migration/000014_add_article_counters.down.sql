@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS "articles_comments_count_idx";
+
+--bun:split
+DROP INDEX IF EXISTS "articles_favorites_count_idx";
+
+--bun:split
+ALTER TABLE "articles" DROP COLUMN IF EXISTS "comments_count";
+
+--bun:split
+ALTER TABLE "articles" DROP COLUMN IF EXISTS "favorites_count";
\ No newline at end of file
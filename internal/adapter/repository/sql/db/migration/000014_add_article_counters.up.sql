@@ -0,0 +1,13 @@
+ALTER TABLE "articles" ADD COLUMN "favorites_count" integer NOT NULL DEFAULT 0;
+
+--bun:split
+ALTER TABLE "articles" ADD COLUMN "comments_count" integer NOT NULL DEFAULT 0;
+
+--bun:split
+UPDATE "articles" AS a SET "favorites_count" = (SELECT count(*) FROM "article_favorites" AS af WHERE af.article_id = a.id), "comments_count" = (SELECT count(*) FROM "comments" AS c WHERE c.article_id = a.id);
+
+--bun:split
+CREATE INDEX "articles_favorites_count_idx" ON "articles" ("favorites_count" DESC, "created_at" DESC, "id" DESC);
+
+--bun:split
+CREATE INDEX "articles_comments_count_idx" ON "articles" ("comments_count" DESC, "created_at" DESC, "id" DESC);
\ No newline at end of file
@@ -1,6 +1,8 @@
 package sql
 
 import (
+	"errors"
+
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
@@ -36,3 +38,12 @@ func intoException(err error) *exception.Exception {
 	}
 	return exception.Into(err)
 }
+
+// isSerializationFailure check error returned by callback or commit of transaction
+func isSerializationFailure(err error) bool {
+	if fail, ok := err.(*exception.Exception); ok {
+		return isSerializationFailure(fail.Cause)
+	}
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "40001"
+}
@@ -0,0 +1,37 @@
+package sql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/sql/db/migration"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+)
+
+// Health read migration version written by golang-migrate, query also ping database
+func (r *sqlRepo) Health(ctx context.Context) (port.RepositoryHealth, error) {
+	health := port.RepositoryHealth{
+		Database:        TypePostgres,
+		LatestMigration: latestMigration(),
+	}
+	var version int64
+	err := r.db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &health.MigrationDirty)
+	if err != nil {
+		return health, intoException(err)
+	}
+	health.MigrationVersion = uint(version)
+	return health, nil
+}
+
+// latestMigration version of newest migration file
+func latestMigration() uint {
+	migrations := migration.Migrations.Sorted()
+	if len(migrations) == 0 {
+		return 0
+	}
+	version, err := strconv.ParseUint(migrations[len(migrations)-1].Name, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return uint(version)
+}
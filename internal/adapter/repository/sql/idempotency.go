@@ -0,0 +1,109 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/sql/model"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/uptrace/bun"
+)
+
+type idempotencyRepo struct {
+	db bun.IDB
+}
+
+func NewIdempotencyRepository(db bun.IDB) port.IdempotencyRepository {
+	return &idempotencyRepo{
+		db: db,
+	}
+}
+
+func (r *idempotencyRepo) AddIdempotency(ctx context.Context, arg domain.Idempotency) (bool, error) {
+	idempotency := model.AsIdempotency(arg)
+	result, err := r.db.NewInsert().
+		Model(&idempotency).
+		On("CONFLICT (user_id, key) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return false, intoException(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, intoException(err)
+	}
+	return affected > 0, nil
+}
+
+func (r *idempotencyRepo) FilterIdempotency(ctx context.Context, filter port.FilterIdempotencyPayload) ([]domain.Idempotency, error) {
+	idempotencies := []model.Idempotency{}
+	query := r.db.NewSelect().Model(&idempotencies)
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN (?)", bun.In(filter.IDs))
+	}
+	if len(filter.UserIDs) > 0 {
+		query = query.Where("user_id IN (?)", bun.In(filter.UserIDs))
+	}
+	if len(filter.Keys) > 0 {
+		query = query.Where("key IN (?)", bun.In(filter.Keys))
+	}
+	if !filter.ExpiredBefore.IsZero() {
+		query = query.Where("expired_at <= ?", filter.ExpiredBefore)
+	}
+	err := query.Scan(ctx)
+	if err != nil {
+		return []domain.Idempotency{}, intoException(err)
+	}
+	result := []domain.Idempotency{}
+	for _, idempotency := range idempotencies {
+		result = append(result, idempotency.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *idempotencyRepo) UpdateIdempotency(ctx context.Context, arg domain.Idempotency) (domain.Idempotency, error) {
+	idempotency := model.AsIdempotency(arg)
+	_, err := r.db.NewUpdate().
+		Model(&idempotency).
+		Column("status", "response_code", "response", "expired_at").
+		Where("id = ?", idempotency.ID).
+		Exec(ctx)
+	if err != nil {
+		return domain.Idempotency{}, intoException(err)
+	}
+
+	idempotencies, err := r.FilterIdempotency(ctx, port.FilterIdempotencyPayload{IDs: []domain.ID{idempotency.ID}})
+	if err != nil {
+		return domain.Idempotency{}, intoException(err)
+	}
+	if len(idempotencies) == 0 {
+		return domain.Idempotency{}, exception.New(exception.TypeNotFound, "idempotency key not found", nil)
+	}
+	return idempotencies[0], nil
+}
+
+func (r *idempotencyRepo) DeleteIdempotency(ctx context.Context, filter port.FilterIdempotencyPayload) (int, error) {
+	query := r.db.NewDelete().Model((*model.Idempotency)(nil))
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN (?)", bun.In(filter.IDs))
+	}
+	if len(filter.UserIDs) > 0 {
+		query = query.Where("user_id IN (?)", bun.In(filter.UserIDs))
+	}
+	if len(filter.Keys) > 0 {
+		query = query.Where("key IN (?)", bun.In(filter.Keys))
+	}
+	if !filter.ExpiredBefore.IsZero() {
+		query = query.Where("expired_at <= ?", filter.ExpiredBefore)
+	}
+	result, err := query.Exec(ctx)
+	if err != nil {
+		return 0, intoException(err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, intoException(err)
+	}
+	return int(affected), nil
+}
@@ -17,6 +17,7 @@ type Article struct {
 	Body          string    `bun:"body,notnull"`
 	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
 	UpdatedAt     time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	Version       int       `bun:"version,notnull,default:1"`
 }
 
 func (data Article) ToDomain() domain.Article {
@@ -29,6 +30,7 @@ func (data Article) ToDomain() domain.Article {
 		Body:        data.Body,
 		CreatedAt:   data.CreatedAt,
 		UpdatedAt:   data.UpdatedAt,
+		Version:     data.Version,
 	}
 }
 
@@ -42,6 +44,7 @@ func AsArticle(arg domain.Article) Article {
 		Body:        arg.Body,
 		CreatedAt:   arg.CreatedAt,
 		UpdatedAt:   arg.UpdatedAt,
+		Version:     arg.Version,
 	}
 }
 
@@ -90,7 +93,11 @@ type Comment struct {
 	ID            domain.ID `bun:"id,pk"`
 	ArticleID     domain.ID `bun:"article_id,notnull"`
 	AuthorID      domain.ID `bun:"author_id,notnull"`
+	ParentID      domain.ID `bun:"parent_id,nullzero"`
+	Depth         int       `bun:"depth,notnull"`
 	Body          string    `bun:"body,notnull"`
+	IsDeleted     bool      `bun:"is_deleted,notnull"`
+	IsEdited      bool      `bun:"is_edited,notnull"`
 	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
 	UpdatedAt     time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
 }
@@ -100,7 +107,11 @@ func (data Comment) ToDomain() domain.Comment {
 		ID:        data.ID,
 		ArticleID: data.ArticleID,
 		AuthorID:  data.AuthorID,
+		ParentID:  data.ParentID,
+		Depth:     data.Depth,
 		Body:      data.Body,
+		IsDeleted: data.IsDeleted,
+		IsEdited:  data.IsEdited,
 		CreatedAt: data.CreatedAt,
 		UpdatedAt: data.UpdatedAt,
 	}
@@ -111,12 +122,74 @@ func AsComment(arg domain.Comment) Comment {
 		ID:        arg.ID,
 		ArticleID: arg.ArticleID,
 		AuthorID:  arg.AuthorID,
+		ParentID:  arg.ParentID,
+		Depth:     arg.Depth,
 		Body:      arg.Body,
+		IsDeleted: arg.IsDeleted,
+		IsEdited:  arg.IsEdited,
 		CreatedAt: arg.CreatedAt,
 		UpdatedAt: arg.UpdatedAt,
 	}
 }
 
+type CommentRevision struct {
+	bun.BaseModel `bun:"table:comment_revisions,alias:cr"`
+	ID            domain.ID `bun:"id,pk"`
+	CommentID     domain.ID `bun:"comment_id,notnull"`
+	Body          string    `bun:"body,notnull"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+func (data CommentRevision) ToDomain() domain.CommentRevision {
+	return domain.CommentRevision{
+		ID:        data.ID,
+		CommentID: data.CommentID,
+		Body:      data.Body,
+		CreatedAt: data.CreatedAt,
+	}
+}
+
+func AsCommentRevision(arg domain.CommentRevision) CommentRevision {
+	return CommentRevision{
+		ID:        arg.ID,
+		CommentID: arg.CommentID,
+		Body:      arg.Body,
+		CreatedAt: arg.CreatedAt,
+	}
+}
+
+type Mention struct {
+	bun.BaseModel `bun:"table:mentions,alias:m"`
+	ID            domain.ID `bun:"id,pk"`
+	UserID        domain.ID `bun:"user_id,notnull"`
+	AuthorID      domain.ID `bun:"author_id,notnull"`
+	ArticleID     domain.ID `bun:"article_id,notnull"`
+	CommentID     domain.ID `bun:"comment_id,nullzero"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+func (data Mention) ToDomain() domain.Mention {
+	return domain.Mention{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		AuthorID:  data.AuthorID,
+		ArticleID: data.ArticleID,
+		CommentID: data.CommentID,
+		CreatedAt: data.CreatedAt,
+	}
+}
+
+func AsMention(arg domain.Mention) Mention {
+	return Mention{
+		ID:        arg.ID,
+		UserID:    arg.UserID,
+		AuthorID:  arg.AuthorID,
+		ArticleID: arg.ArticleID,
+		CommentID: arg.CommentID,
+		CreatedAt: arg.CreatedAt,
+	}
+}
+
 type ArticleFavorite struct {
 	bun.BaseModel `bun:"table:article_favorites,alias:af"`
 	ArticleID     domain.ID `bun:"article_id,notnull"`
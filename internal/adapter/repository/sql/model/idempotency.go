@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/uptrace/bun"
+)
+
+type Idempotency struct {
+	bun.BaseModel `bun:"table:idempotency_keys,alias:ik"`
+	ID            domain.ID `bun:"id,pk"`
+	UserID        domain.ID `bun:"user_id,notnull"`
+	Key           string    `bun:"key,notnull"`
+	RequestHash   string    `bun:"request_hash,notnull"`
+	Status        string    `bun:"status,notnull"`
+	ResponseCode  int       `bun:"response_code,notnull"`
+	Response      []byte    `bun:"response,nullzero"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	ExpiredAt     time.Time `bun:"expired_at,notnull"`
+}
+
+func (data Idempotency) ToDomain() domain.Idempotency {
+	return domain.Idempotency{
+		ID:           data.ID,
+		UserID:       data.UserID,
+		Key:          data.Key,
+		RequestHash:  data.RequestHash,
+		Status:       data.Status,
+		ResponseCode: data.ResponseCode,
+		Response:     data.Response,
+		CreatedAt:    data.CreatedAt,
+		ExpiredAt:    data.ExpiredAt,
+	}
+}
+
+func AsIdempotency(arg domain.Idempotency) Idempotency {
+	return Idempotency{
+		ID:           arg.ID,
+		UserID:       arg.UserID,
+		Key:          arg.Key,
+		RequestHash:  arg.RequestHash,
+		Status:       arg.Status,
+		ResponseCode: arg.ResponseCode,
+		Response:     arg.Response,
+		CreatedAt:    arg.CreatedAt,
+		ExpiredAt:    arg.ExpiredAt,
+	}
+}
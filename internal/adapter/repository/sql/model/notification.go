@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/uptrace/bun"
+)
+
+type Notification struct {
+	bun.BaseModel `bun:"table:notifications,alias:n"`
+	ID            domain.ID `bun:"id,pk"`
+	UserID        domain.ID `bun:"user_id,notnull"`
+	ActorID       domain.ID `bun:"actor_id,notnull"`
+	Type          string    `bun:"type,notnull"`
+	ArticleID     domain.ID `bun:"article_id,nullzero"`
+	CommentID     domain.ID `bun:"comment_id,nullzero"`
+	ReadAt        time.Time `bun:"read_at,nullzero"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+func (data Notification) ToDomain() domain.Notification {
+	return domain.Notification{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		ActorID:   data.ActorID,
+		Type:      data.Type,
+		ArticleID: data.ArticleID,
+		CommentID: data.CommentID,
+		ReadAt:    data.ReadAt,
+		CreatedAt: data.CreatedAt,
+	}
+}
+
+func AsNotification(arg domain.Notification) Notification {
+	return Notification{
+		ID:        arg.ID,
+		UserID:    arg.UserID,
+		ActorID:   arg.ActorID,
+		Type:      arg.Type,
+		ArticleID: arg.ArticleID,
+		CommentID: arg.CommentID,
+		ReadAt:    arg.ReadAt,
+		CreatedAt: arg.CreatedAt,
+	}
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/uptrace/bun"
+)
+
+type OutboxEvent struct {
+	bun.BaseModel `bun:"table:outbox_events,alias:oe"`
+	ID            domain.ID `bun:"id,pk"`
+	Type          string    `bun:"type,notnull"`
+	DedupeKey     string    `bun:"dedupe_key,notnull"`
+	UserID        domain.ID `bun:"user_id,nullzero"`
+	Payload       string    `bun:"payload,notnull"`
+	Status        string    `bun:"status,notnull"`
+	Attempts      int       `bun:"attempts,notnull"`
+	Error         string    `bun:"error,notnull"`
+	AvailableAt   time.Time `bun:"available_at,nullzero,notnull,default:current_timestamp"`
+	ProcessedAt   time.Time `bun:"processed_at,nullzero"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+}
+
+func (data OutboxEvent) ToDomain() domain.OutboxEvent {
+	return domain.OutboxEvent{
+		ID:          data.ID,
+		Type:        data.Type,
+		DedupeKey:   data.DedupeKey,
+		UserID:      data.UserID,
+		Payload:     data.Payload,
+		Status:      data.Status,
+		Attempts:    data.Attempts,
+		Error:       data.Error,
+		AvailableAt: data.AvailableAt,
+		ProcessedAt: data.ProcessedAt,
+		CreatedAt:   data.CreatedAt,
+	}
+}
+
+func AsOutboxEvent(arg domain.OutboxEvent) OutboxEvent {
+	return OutboxEvent{
+		ID:          arg.ID,
+		Type:        arg.Type,
+		DedupeKey:   arg.DedupeKey,
+		UserID:      arg.UserID,
+		Payload:     arg.Payload,
+		Status:      arg.Status,
+		Attempts:    arg.Attempts,
+		Error:       arg.Error,
+		AvailableAt: arg.AvailableAt,
+		ProcessedAt: arg.ProcessedAt,
+		CreatedAt:   arg.CreatedAt,
+	}
+}
@@ -17,6 +17,7 @@ type User struct {
 	Bio           string    `bun:"bio"`
 	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
 	UpdatedAt     time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+	Version       int       `bun:"version,notnull,default:1"`
 }
 
 func (data User) ToDomain() domain.User {
@@ -29,6 +30,7 @@ func (data User) ToDomain() domain.User {
 		Bio:       data.Bio,
 		CreatedAt: data.CreatedAt,
 		UpdatedAt: data.UpdatedAt,
+		Version:   data.Version,
 	}
 }
 
@@ -42,6 +44,7 @@ func AsUser(arg domain.User) User {
 		Bio:       arg.Bio,
 		CreatedAt: arg.CreatedAt,
 		UpdatedAt: arg.UpdatedAt,
+		Version:   arg.Version,
 	}
 }
 
@@ -49,12 +52,14 @@ type UserFollow struct {
 	bun.BaseModel `bun:"table:user_follows,alias:uf"`
 	FollowerID    domain.ID `bun:"follower_id"`
 	FolloweeID    domain.ID `bun:"followee_id"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
 }
 
 func (data UserFollow) ToDomain() domain.UserFollow {
 	return domain.UserFollow{
 		FollowerID: data.FollowerID,
 		FolloweeID: data.FolloweeID,
+		CreatedAt:  data.CreatedAt,
 	}
 }
 
@@ -62,5 +67,6 @@ func AsUserFollow(arg domain.UserFollow) UserFollow {
 	return UserFollow{
 		FollowerID: arg.FollowerID,
 		FolloweeID: arg.FolloweeID,
+		CreatedAt:  arg.CreatedAt,
 	}
 }
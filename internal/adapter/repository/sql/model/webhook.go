@@ -0,0 +1,90 @@
+package model
+
+import (
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/uptrace/bun"
+)
+
+type Webhook struct {
+	bun.BaseModel `bun:"table:webhooks,alias:w"`
+	ID            domain.ID `bun:"id,pk"`
+	UserID        domain.ID `bun:"user_id,notnull"`
+	URL           string    `bun:"url,notnull"`
+	Secret        string    `bun:"secret,notnull"`
+	Events        []string  `bun:"events,array"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt     time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+func (data Webhook) ToDomain() domain.Webhook {
+	return domain.Webhook{
+		ID:        data.ID,
+		UserID:    data.UserID,
+		URL:       data.URL,
+		Secret:    data.Secret,
+		Events:    data.Events,
+		CreatedAt: data.CreatedAt,
+		UpdatedAt: data.UpdatedAt,
+	}
+}
+
+func AsWebhook(arg domain.Webhook) Webhook {
+	return Webhook{
+		ID:        arg.ID,
+		UserID:    arg.UserID,
+		URL:       arg.URL,
+		Secret:    arg.Secret,
+		Events:    arg.Events,
+		CreatedAt: arg.CreatedAt,
+		UpdatedAt: arg.UpdatedAt,
+	}
+}
+
+type WebhookDelivery struct {
+	bun.BaseModel `bun:"table:webhook_deliveries,alias:wd"`
+	ID            domain.ID `bun:"id,pk"`
+	WebhookID     domain.ID `bun:"webhook_id,notnull"`
+	EventID       domain.ID `bun:"event_id,nullzero"`
+	Event         string    `bun:"event,notnull"`
+	Payload       string    `bun:"payload,notnull"`
+	Status        string    `bun:"status,notnull"`
+	Attempts      int       `bun:"attempts,notnull"`
+	ResponseCode  int       `bun:"response_code,notnull"`
+	Error         string    `bun:"error,notnull"`
+	CreatedAt     time.Time `bun:"created_at,nullzero,notnull,default:current_timestamp"`
+	UpdatedAt     time.Time `bun:"updated_at,nullzero,notnull,default:current_timestamp"`
+}
+
+func (data WebhookDelivery) ToDomain() domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID:           data.ID,
+		WebhookID:    data.WebhookID,
+		EventID:      data.EventID,
+		Event:        data.Event,
+		Payload:      data.Payload,
+		Status:       data.Status,
+		Attempts:     data.Attempts,
+		ResponseCode: data.ResponseCode,
+		Error:        data.Error,
+		CreatedAt:    data.CreatedAt,
+		UpdatedAt:    data.UpdatedAt,
+	}
+}
+
+func AsWebhookDelivery(arg domain.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		ID:           arg.ID,
+		WebhookID:    arg.WebhookID,
+		EventID:      arg.EventID,
+		Event:        arg.Event,
+		Payload:      arg.Payload,
+		Status:       arg.Status,
+		Attempts:     arg.Attempts,
+		ResponseCode: arg.ResponseCode,
+		Error:        arg.Error,
+		CreatedAt:    arg.CreatedAt,
+		UpdatedAt:    arg.UpdatedAt,
+	}
+}
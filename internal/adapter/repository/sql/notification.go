@@ -0,0 +1,97 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/sql/model"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/uptrace/bun"
+)
+
+type notificationRepo struct {
+	db bun.IDB
+}
+
+func NewNotificationRepository(db bun.IDB) port.NotificationRepository {
+	return &notificationRepo{
+		db: db,
+	}
+}
+
+func (r *notificationRepo) AddNotifications(ctx context.Context, arg []domain.Notification) ([]domain.Notification, error) {
+	if len(arg) == 0 {
+		return []domain.Notification{}, nil
+	}
+	notifications := make([]model.Notification, len(arg))
+	for i, notification := range arg {
+		notifications[i] = model.AsNotification(notification)
+	}
+	_, err := r.db.NewInsert().Model(&notifications).Exec(ctx)
+	if err != nil {
+		return []domain.Notification{}, intoException(err)
+	}
+	result := []domain.Notification{}
+	for _, notification := range notifications {
+		result = append(result, notification.ToDomain())
+	}
+	return result, nil
+}
+
+// whereNotification apply filter shared by FilterNotification and CountNotification
+func whereNotification(query *bun.SelectQuery, filter port.FilterNotificationPayload) *bun.SelectQuery {
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN (?)", bun.In(filter.IDs))
+	}
+	if len(filter.UserIDs) > 0 {
+		query = query.Where("user_id IN (?)", bun.In(filter.UserIDs))
+	}
+	if filter.UnreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	return query
+}
+
+func (r *notificationRepo) FilterNotification(ctx context.Context, filter port.FilterNotificationPayload) ([]domain.Notification, error) {
+	notifications := []model.Notification{}
+	query := whereNotification(r.db.NewSelect().Model(&notifications), filter)
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	err := query.Order("created_at DESC", "id DESC").Scan(ctx)
+	if err != nil {
+		return []domain.Notification{}, intoException(err)
+	}
+	result := []domain.Notification{}
+	for _, notification := range notifications {
+		result = append(result, notification.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *notificationRepo) CountNotification(ctx context.Context, filter port.FilterNotificationPayload) (int, error) {
+	count, err := whereNotification(r.db.NewSelect().Model((*model.Notification)(nil)), filter).Count(ctx)
+	if err != nil {
+		return 0, intoException(err)
+	}
+	return count, nil
+}
+
+func (r *notificationRepo) MarkNotificationRead(ctx context.Context, arg port.MarkNotificationReadPayload) error {
+	query := r.db.NewUpdate().
+		Model((*model.Notification)(nil)).
+		Set("read_at = ?", arg.ReadAt).
+		Where("user_id = ?", arg.UserID).
+		Where("read_at IS NULL")
+	if len(arg.IDs) > 0 {
+		query = query.Where("id IN (?)", bun.In(arg.IDs))
+	}
+	_, err := query.Exec(ctx)
+	if err != nil {
+		return intoException(err)
+	}
+	return nil
+}
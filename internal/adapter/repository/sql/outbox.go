@@ -0,0 +1,126 @@
+package sql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/sql/model"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/uptrace/bun"
+)
+
+type outboxRepo struct {
+	db bun.IDB
+}
+
+func NewOutboxRepository(db bun.IDB) port.OutboxRepository {
+	return &outboxRepo{
+		db: db,
+	}
+}
+
+func (r *outboxRepo) AddOutboxEvents(ctx context.Context, arg []domain.OutboxEvent) error {
+	if len(arg) == 0 {
+		return nil
+	}
+	events := make([]model.OutboxEvent, len(arg))
+	for i, event := range arg {
+		events[i] = model.AsOutboxEvent(event)
+	}
+	_, err := r.db.NewInsert().
+		Model(&events).
+		On("CONFLICT (dedupe_key) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return intoException(err)
+	}
+	return nil
+}
+
+func (r *outboxRepo) FilterOutboxEvent(ctx context.Context, filter port.FilterOutboxPayload) ([]domain.OutboxEvent, error) {
+	events := []model.OutboxEvent{}
+	query := r.db.NewSelect().Model(&events)
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN (?)", bun.In(filter.IDs))
+	}
+	if len(filter.DedupeKeys) > 0 {
+		query = query.Where("dedupe_key IN (?)", bun.In(filter.DedupeKeys))
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN (?)", bun.In(filter.Statuses))
+	}
+	if !filter.AvailableBefore.IsZero() {
+		query = query.Where("available_at <= ?", filter.AvailableBefore)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	err := query.Order("created_at ASC", "id ASC").Scan(ctx)
+	if err != nil {
+		return []domain.OutboxEvent{}, intoException(err)
+	}
+	result := []domain.OutboxEvent{}
+	for _, event := range events {
+		result = append(result, event.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *outboxRepo) ClaimOutboxEvents(ctx context.Context, arg port.ClaimOutboxPayload) ([]domain.OutboxEvent, error) {
+	// skip locked, row claimed by concurrent relay is not waited
+	available := r.db.NewSelect().
+		Model((*model.OutboxEvent)(nil)).
+		Column("id").
+		Where("status = ?", domain.OutboxStatusPending).
+		Where("available_at <= ?", arg.AvailableBefore).
+		Order("created_at ASC", "id ASC").
+		Limit(arg.Limit).
+		For("UPDATE SKIP LOCKED")
+
+	events := []model.OutboxEvent{}
+	_, err := r.db.NewUpdate().
+		Model((*model.OutboxEvent)(nil)).
+		Set("available_at = ?", arg.LeaseUntil).
+		Where("id IN (?)", available).
+		Returning("*").
+		Exec(ctx, &events)
+	if err != nil {
+		return []domain.OutboxEvent{}, intoException(err)
+	}
+
+	// returning has no order
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].CreatedAt.Before(events[j].CreatedAt)
+		}
+		return events[i].ID < events[j].ID
+	})
+	result := []domain.OutboxEvent{}
+	for _, event := range events {
+		result = append(result, event.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *outboxRepo) UpdateOutboxEvent(ctx context.Context, arg domain.OutboxEvent) (domain.OutboxEvent, error) {
+	event := model.AsOutboxEvent(arg)
+	_, err := r.db.NewUpdate().
+		Model(&event).
+		Column("status", "attempts", "error", "available_at", "processed_at").
+		Where("id = ?", event.ID).
+		Exec(ctx)
+	if err != nil {
+		return domain.OutboxEvent{}, intoException(err)
+	}
+
+	events, err := r.FilterOutboxEvent(ctx, port.FilterOutboxPayload{IDs: []domain.ID{event.ID}})
+	if err != nil {
+		return domain.OutboxEvent{}, intoException(err)
+	}
+	if len(events) == 0 {
+		return domain.OutboxEvent{}, exception.New(exception.TypeNotFound, "outbox event not found", nil)
+	}
+	return events[0], nil
+}
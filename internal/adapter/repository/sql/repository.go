@@ -10,13 +10,20 @@ import (
 	"github.com/uptrace/bun"
 )
 
-const TypePostgres = "postgres"
+const (
+	TypePostgres      = "postgres"
+	atomicMaxAttempts = 3
+)
 
 type sqlRepo struct {
 	db          bun.IDB
 	logger      port.Logger
 	userRepo    port.UserRepository
 	articleRepo port.ArticleRepository
+	notifRepo   port.NotificationRepository
+	webhookRepo port.WebhookRepository
+	outboxRepo  port.OutboxRepository
+	idemRepo    port.IdempotencyRepository
 }
 
 func NewSQLRepository(config util.Config, logger port.Logger) (port.Repository, error) {
@@ -28,25 +35,50 @@ func NewSQLRepository(config util.Config, logger port.Logger) (port.Repository,
 }
 
 func (r *sqlRepo) Atomic(ctx context.Context, fn port.RepositoryAtomicCallback) error {
-	err := r.db.RunInTx(
-		ctx,
-		&sql.TxOptions{Isolation: sql.LevelSerializable},
-		func(ctx context.Context, tx bun.Tx) error {
-			return fn(create(tx, r.logger))
-		},
-	)
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = r.db.RunInTx(
+			ctx,
+			&sql.TxOptions{Isolation: sql.LevelSerializable},
+			func(ctx context.Context, tx bun.Tx) error {
+				return fn(create(tx, r.logger))
+			},
+		)
+		// concurrent update of the same row such as article counter fail serializable transaction,
+		// retry from the start, nested transaction is retried by the outer one
+		_, isRoot := r.db.(*bun.DB)
+		if err == nil || !isRoot || attempt >= atomicMaxAttempts || !isSerializationFailure(err) {
+			break
+		}
+	}
 	if err != nil {
 		return intoException(err)
 	}
 	return nil
 }
 
+// Close database pool, transaction repository does not own connection
+func (r *sqlRepo) Close(ctx context.Context) error {
+	database, ok := r.db.(*bun.DB)
+	if !ok {
+		return nil
+	}
+	if err := database.Close(); err != nil {
+		return intoException(err)
+	}
+	return nil
+}
+
 func create(db bun.IDB, logger port.Logger) port.Repository {
 	return &sqlRepo{
 		db:          db,
 		logger:      logger,
 		userRepo:    NewUserRepository(db),
 		articleRepo: NewArticleRepository(db),
+		notifRepo:   NewNotificationRepository(db),
+		webhookRepo: NewWebhookRepository(db),
+		outboxRepo:  NewOutboxRepository(db),
+		idemRepo:    NewIdempotencyRepository(db),
 	}
 }
 
@@ -57,3 +89,19 @@ func (r *sqlRepo) User() port.UserRepository {
 func (r *sqlRepo) Article() port.ArticleRepository {
 	return r.articleRepo
 }
+
+func (r *sqlRepo) Notification() port.NotificationRepository {
+	return r.notifRepo
+}
+
+func (r *sqlRepo) Webhook() port.WebhookRepository {
+	return r.webhookRepo
+}
+
+func (r *sqlRepo) Outbox() port.OutboxRepository {
+	return r.outboxRepo
+}
+
+func (r *sqlRepo) Idempotency() port.IdempotencyRepository {
+	return r.idemRepo
+}
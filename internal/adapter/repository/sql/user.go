@@ -45,12 +45,20 @@ func (r *userRepo) UpdateUser(ctx context.Context, arg domain.User) (domain.User
 		arg.Username = ""
 	}
 
-	// update
+	// update, only expected version when provided
 	req := model.AsUser(arg)
-	_, err = r.db.NewUpdate().Model(&req).OmitZero().Where("id = ?", req.ID).Exec(ctx)
+	req.Version = 0 // omitted, incremented below
+	query := r.db.NewUpdate().Model(&req).OmitZero().Value("version", "version + 1").Where("id = ?", req.ID)
+	if arg.Version > 0 {
+		query = query.Where("version = ?", arg.Version)
+	}
+	res, err := query.Exec(ctx)
 	if err != nil {
 		return domain.User{}, intoException(err)
 	}
+	if affected, err := res.RowsAffected(); err == nil && affected == 0 {
+		return domain.User{}, exception.New(exception.TypeConflict, "user has been modified", nil)
+	}
 
 	// find updated
 	updated, err := r.FindOne(ctx, port.FilterUserPayload{IDs: []domain.ID{req.ID}})
@@ -0,0 +1,125 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/sql/model"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
+)
+
+type webhookRepo struct {
+	db bun.IDB
+}
+
+func NewWebhookRepository(db bun.IDB) port.WebhookRepository {
+	return &webhookRepo{
+		db: db,
+	}
+}
+
+func (r *webhookRepo) CreateWebhook(ctx context.Context, arg domain.Webhook) (domain.Webhook, error) {
+	webhook := model.AsWebhook(arg)
+	_, err := r.db.NewInsert().Model(&webhook).Exec(ctx)
+	if err != nil {
+		return domain.Webhook{}, intoException(err)
+	}
+	return webhook.ToDomain(), nil
+}
+
+func (r *webhookRepo) FilterWebhook(ctx context.Context, filter port.FilterWebhookPayload) ([]domain.Webhook, error) {
+	webhooks := []model.Webhook{}
+	query := r.db.NewSelect().Model(&webhooks)
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN (?)", bun.In(filter.IDs))
+	}
+	if len(filter.UserIDs) > 0 {
+		query = query.Where("user_id IN (?)", bun.In(filter.UserIDs))
+	}
+	if len(filter.Events) > 0 {
+		query = query.Where("events && ?", pgdialect.Array(filter.Events))
+	}
+	err := query.Order("created_at DESC", "id DESC").Scan(ctx)
+	if err != nil {
+		return []domain.Webhook{}, intoException(err)
+	}
+	result := []domain.Webhook{}
+	for _, webhook := range webhooks {
+		result = append(result, webhook.ToDomain())
+	}
+	return result, nil
+}
+
+func (r *webhookRepo) DeleteWebhook(ctx context.Context, arg domain.Webhook) error {
+	webhook := model.AsWebhook(arg)
+	_, err := r.db.NewDelete().
+		Model(&webhook).
+		Where("id = ?", webhook.ID).
+		Exec(ctx)
+	if err != nil {
+		return intoException(err)
+	}
+	return nil
+}
+
+func (r *webhookRepo) AddWebhookDelivery(ctx context.Context, arg domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	delivery := model.AsWebhookDelivery(arg)
+	_, err := r.db.NewInsert().Model(&delivery).Exec(ctx)
+	if err != nil {
+		return domain.WebhookDelivery{}, intoException(err)
+	}
+	return delivery.ToDomain(), nil
+}
+
+func (r *webhookRepo) UpdateWebhookDelivery(ctx context.Context, arg domain.WebhookDelivery) (domain.WebhookDelivery, error) {
+	delivery := model.AsWebhookDelivery(arg)
+	_, err := r.db.NewUpdate().
+		Model(&delivery).
+		Column("status", "attempts", "response_code", "error", "updated_at").
+		Where("id = ?", delivery.ID).
+		Exec(ctx)
+	if err != nil {
+		return domain.WebhookDelivery{}, intoException(err)
+	}
+
+	deliveries, err := r.FilterWebhookDelivery(ctx, port.FilterWebhookDeliveryPayload{IDs: []domain.ID{delivery.ID}})
+	if err != nil {
+		return domain.WebhookDelivery{}, intoException(err)
+	}
+	if len(deliveries) == 0 {
+		return domain.WebhookDelivery{}, exception.New(exception.TypeNotFound, "webhook delivery not found", nil)
+	}
+	return deliveries[0], nil
+}
+
+func (r *webhookRepo) FilterWebhookDelivery(ctx context.Context, filter port.FilterWebhookDeliveryPayload) ([]domain.WebhookDelivery, error) {
+	deliveries := []model.WebhookDelivery{}
+	query := r.db.NewSelect().Model(&deliveries)
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN (?)", bun.In(filter.IDs))
+	}
+	if len(filter.WebhookIDs) > 0 {
+		query = query.Where("webhook_id IN (?)", bun.In(filter.WebhookIDs))
+	}
+	if len(filter.EventIDs) > 0 {
+		query = query.Where("event_id IN (?)", bun.In(filter.EventIDs))
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	err := query.Order("created_at DESC", "id DESC").Scan(ctx)
+	if err != nil {
+		return []domain.WebhookDelivery{}, intoException(err)
+	}
+	result := []domain.WebhookDelivery{}
+	for _, delivery := range deliveries {
+		result = append(result, delivery.ToDomain())
+	}
+	return result, nil
+}
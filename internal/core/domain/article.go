@@ -16,6 +16,7 @@ type Article struct {
 	Body          string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	Version       int // incremented on each update, for optimistic locking
 	TagNames      []string
 	Author        User
 	IsFavorite    bool
@@ -37,6 +38,7 @@ func NewArticle(arg Article) Article {
 		Body:        arg.Body,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		Version:     1,
 	}
 	article.SetTitle(arg.Title)
 	return article
@@ -69,14 +71,43 @@ type ArticleTag struct {
 	TagID     ID
 }
 
+const (
+	CommentMaxDepth    = 5           // root comment depth is 0
+	CommentDeletedBody = "[deleted]" // tombstone body of deleted comment that has replies
+)
+
 type Comment struct {
 	ID        ID
 	ArticleID ID
 	AuthorID  ID
+	ParentID  ID // empty for root comment
+	Depth     int
 	Body      string
+	IsDeleted bool
+	IsEdited  bool
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Author    User
+	Replies   []Comment
+}
+
+// Edit replace comment body, return revision that keep previous body
+func (comment *Comment) Edit(body string) CommentRevision {
+	revision := NewCommentRevision(CommentRevision{
+		CommentID: comment.ID,
+		Body:      comment.Body,
+	})
+	comment.Body = body
+	comment.IsEdited = true
+	comment.UpdatedAt = revision.CreatedAt
+	return revision
+}
+
+// Tombstone mark comment as deleted but keep it for its replies
+func (comment *Comment) Tombstone() {
+	comment.Body = CommentDeletedBody
+	comment.IsDeleted = true
+	comment.UpdatedAt = time.Now()
 }
 
 func NewComment(arg Comment) Comment {
@@ -85,12 +116,30 @@ func NewComment(arg Comment) Comment {
 		ID:        NewID(),
 		ArticleID: arg.ArticleID,
 		AuthorID:  arg.AuthorID,
+		ParentID:  arg.ParentID,
+		Depth:     arg.Depth,
 		Body:      arg.Body,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
 }
 
+type CommentRevision struct {
+	ID        ID
+	CommentID ID
+	Body      string
+	CreatedAt time.Time
+}
+
+func NewCommentRevision(arg CommentRevision) CommentRevision {
+	return CommentRevision{
+		ID:        NewID(),
+		CommentID: arg.CommentID,
+		Body:      arg.Body,
+		CreatedAt: time.Now(),
+	}
+}
+
 type ArticleFavorite struct {
 	ArticleID ID
 	UserID    ID
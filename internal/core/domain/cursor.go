@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor point to last item of a page in keyset pagination
+// items are ordered by created_at then by id (ULID, also time ordered)
+type Cursor struct {
+	CreatedAt time.Time
+	ID        ID
+}
+
+func NewCursor(createdAt time.Time, id ID) Cursor {
+	return Cursor{
+		CreatedAt: createdAt,
+		ID:        id,
+	}
+}
+
+func (c Cursor) IsZero() bool {
+	return c.ID == "" && c.CreatedAt.IsZero()
+}
+
+// String encode cursor into opaque value
+func (c Cursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	raw := strconv.FormatInt(c.CreatedAt.UnixNano(), 10) + "_" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decode opaque value, empty value is zero cursor
+func ParseCursor(value string) (Cursor, error) {
+	if value == "" {
+		return Cursor{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	nanoStr, idStr, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+	nano, err := strconv.ParseInt(nanoStr, 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	id, err := ParseID(idStr)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return NewCursor(time.Unix(0, nano).UTC(), id), nil
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	cursor := NewCursor(time.Now().UTC(), NewID())
+
+	value := cursor.String()
+	require.NotEmpty(t, value)
+
+	parsed, err := ParseCursor(value)
+	require.Nil(t, err)
+	require.Equal(t, cursor.ID, parsed.ID)
+	require.True(t, cursor.CreatedAt.Equal(parsed.CreatedAt))
+}
+
+func TestCursorEmpty(t *testing.T) {
+	require.Empty(t, Cursor{}.String())
+
+	parsed, err := ParseCursor("")
+	require.Nil(t, err)
+	require.True(t, parsed.IsZero())
+}
+
+func TestCursorInvalid(t *testing.T) {
+	for _, value := range []string{"%%%", "bm9fc2VwYXJhdG9y", "YWJjX2RlZg"} {
+		parsed, err := ParseCursor(value)
+		require.ErrorIs(t, err, ErrInvalidCursor)
+		require.True(t, parsed.IsZero())
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+const (
+	EventTypeArticle        = "article"         // new article for followers feed
+	EventTypeComment        = "comment"         // new comment for article viewers
+	EventTypeCommentDeleted = "comment_deleted" // deleted comment for article viewers
+	EventTypeNotification   = "notification"    // new notification for recipient
+)
+
+// Event real-time update published by service layer.
+// ID is assigned by broker on publish, UserIDs limit recipients
+type Event struct {
+	ID        string
+	Type      string
+	UserIDs   []ID
+	ArticleID ID
+	Data      any
+	CreatedAt time.Time
+}
+
+func NewEvent(arg Event) Event {
+	return Event{
+		Type:      arg.Type,
+		UserIDs:   arg.UserIDs,
+		ArticleID: arg.ArticleID,
+		Data:      arg.Data,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsFor check whether user is event recipient
+func (event Event) IsFor(userID ID) bool {
+	for _, id := range event.UserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsComment check whether event is article comment thread change
+func (event Event) IsComment() bool {
+	return event.Type == EventTypeComment || event.Type == EventTypeCommentDeleted
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	IdempotencyStatusProcessing = "processing"
+	IdempotencyStatusCompleted  = "completed"
+)
+
+// Idempotency stored response of request sent with idempotency key,
+// retry with the same key replay the response instead of run request again
+type Idempotency struct {
+	ID           ID
+	UserID       ID
+	Key          string
+	RequestHash  string // request sent with key, same key cannot be used for other request
+	Status       string
+	ResponseCode int
+	Response     []byte
+	CreatedAt    time.Time
+	ExpiredAt    time.Time
+}
+
+func NewIdempotency(arg Idempotency, ttl time.Duration) Idempotency {
+	now := time.Now()
+	return Idempotency{
+		ID:          NewID(),
+		UserID:      arg.UserID,
+		Key:         arg.Key,
+		RequestHash: arg.RequestHash,
+		Status:      IdempotencyStatusProcessing,
+		CreatedAt:   now,
+		ExpiredAt:   now.Add(ttl),
+	}
+}
+
+func (i Idempotency) IsExpired() bool {
+	return !time.Now().Before(i.ExpiredAt)
+}
+
+func (i Idempotency) IsCompleted() bool {
+	return i.Status == IdempotencyStatusCompleted
+}
+
+// HashIdempotencyRequest hash parts identifying request, scope such as method and path then body
+func HashIdempotencyRequest(parts ...[]byte) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		// length prefix keep boundary between parts
+		hash.Write([]byte{byte(len(part) >> 24), byte(len(part) >> 16), byte(len(part) >> 8), byte(len(part))})
+		hash.Write(part)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+// mention is @ not preceded by word character (skip email), followed by username
+var mentionPattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9_@])@([a-z0-9_]+)`)
+
+// Mention user mentioned in article body or comment (CommentID not empty)
+type Mention struct {
+	ID        ID
+	UserID    ID
+	AuthorID  ID
+	ArticleID ID
+	CommentID ID
+	CreatedAt time.Time
+	Author    User
+	Article   Article
+}
+
+func NewMention(arg Mention) Mention {
+	return Mention{
+		ID:        NewID(),
+		UserID:    arg.UserID,
+		AuthorID:  arg.AuthorID,
+		ArticleID: arg.ArticleID,
+		CommentID: arg.CommentID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// ParseMentions get unique mentioned usernames in order of appearance
+func ParseMentions(text string) []string {
+	usernames := []string{}
+	exists := map[string]bool{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		username := match[1]
+		if exists[username] {
+			continue
+		}
+		exists[username] = true
+		usernames = append(usernames, username)
+	}
+	return usernames
+}
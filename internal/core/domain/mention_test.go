@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMentions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{name: "Empty", text: "", expected: []string{}},
+		{name: "None", text: "hello world", expected: []string{}},
+		{name: "Start", text: "@john_doe hello", expected: []string{"john_doe"}},
+		{name: "Punctuation", text: "thanks (@alice), @bob!", expected: []string{"alice", "bob"}},
+		{name: "Unique", text: "@alice and @bob and @alice", expected: []string{"alice", "bob"}},
+		{name: "Email", text: "mail me at alice@example.com", expected: []string{}},
+		{name: "Double at", text: "@@alice", expected: []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ParseMentions(tc.text))
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+const (
+	NotificationTypeMention  = "mention"  // actor mention user in article or comment
+	NotificationTypeFollow   = "follow"   // actor follow user
+	NotificationTypeFavorite = "favorite" // actor favorite user article
+	NotificationTypeComment  = "comment"  // actor comment on user article
+	NotificationTypeReply    = "reply"    // actor reply user comment
+)
+
+// Notification event for user (recipient) caused by actor
+type Notification struct {
+	ID        ID
+	UserID    ID
+	ActorID   ID
+	Type      string
+	ArticleID ID
+	CommentID ID
+	ReadAt    time.Time // zero when unread
+	CreatedAt time.Time
+	Actor     User
+	Article   Article
+}
+
+func (notification Notification) IsRead() bool {
+	return !notification.ReadAt.IsZero()
+}
+
+func NewNotification(arg Notification) Notification {
+	return Notification{
+		ID:        NewID(),
+		UserID:    arg.UserID,
+		ActorID:   arg.ActorID,
+		Type:      arg.Type,
+		ArticleID: arg.ArticleID,
+		CommentID: arg.CommentID,
+		CreatedAt: time.Now(),
+	}
+}
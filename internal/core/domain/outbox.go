@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// domain event types written to outbox
+const (
+	DomainEventArticleCreated = "article.created"
+	DomainEventArticleUpdated = "article.updated"
+	DomainEventCommentCreated = "comment.created"
+	DomainEventUserFollowed   = "user.followed"
+)
+
+const (
+	OutboxStatusPending   = "pending"
+	OutboxStatusProcessed = "processed"
+	OutboxStatusFailed    = "failed" // attempts run out
+)
+
+// OutboxEvent domain event written in the same transaction as the change,
+// relay dispatch it to handlers after commit at least once.
+// DedupeKey identify the change, outbox keep one event per key
+type OutboxEvent struct {
+	ID          ID
+	Type        string
+	DedupeKey   string
+	UserID      ID     // owner of changed resource
+	Payload     string // json snapshot of the change
+	Status      string
+	Attempts    int
+	Error       string    // last attempt error
+	AvailableAt time.Time // not dispatched before
+	ProcessedAt time.Time
+	CreatedAt   time.Time
+}
+
+func NewOutboxEvent(arg OutboxEvent) OutboxEvent {
+	now := time.Now()
+	return OutboxEvent{
+		ID:          NewID(),
+		Type:        arg.Type,
+		DedupeKey:   arg.DedupeKey,
+		UserID:      arg.UserID,
+		Payload:     arg.Payload,
+		Status:      OutboxStatusPending,
+		AvailableAt: now,
+		CreatedAt:   now,
+	}
+}
+
+// OutboxDedupeKey join event type with parts identifying the change
+func OutboxDedupeKey(eventType string, parts ...any) string {
+	key := eventType
+	for _, part := range parts {
+		key += fmt.Sprintf(":%v", part)
+	}
+	return key
+}
@@ -18,6 +18,7 @@ type User struct {
 	Bio        string
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+	Version    int // incremented on each update, for optimistic locking
 	IsFollowed bool
 	Token      string
 }
@@ -64,6 +65,7 @@ func NewUser(arg User) (User, error) {
 		Bio:       arg.Bio,
 		CreatedAt: now,
 		UpdatedAt: now,
+		Version:   1,
 	}
 
 	if err := user.SetEmail(arg.Email); err != nil {
@@ -104,6 +106,7 @@ func RandomUser() User {
 type UserFollow struct {
 	FollowerID ID
 	FolloweeID ID
+	CreatedAt  time.Time
 }
 
 func NewUserFollow(arg UserFollow) (UserFollow, error) {
@@ -113,6 +116,7 @@ func NewUserFollow(arg UserFollow) (UserFollow, error) {
 	follow := UserFollow{
 		FollowerID: arg.FollowerID,
 		FolloweeID: arg.FolloweeID,
+		CreatedAt:  time.Now(),
 	}
 	return follow, nil
 }
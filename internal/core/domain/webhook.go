@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+)
+
+const (
+	WebhookEventArticleCreated = DomainEventArticleCreated // owner create article
+	WebhookEventArticleUpdated = DomainEventArticleUpdated // owner update article
+	WebhookEventCommentCreated = DomainEventCommentCreated // someone comment on owner article
+	WebhookEventUserFollowed   = DomainEventUserFollowed   // someone follow owner
+)
+
+// WebhookRedeliverEvent outbox event of manual redelivery, not subscribable
+const WebhookRedeliverEvent = "webhook.redeliver"
+
+var WebhookEvents = []string{
+	WebhookEventArticleCreated,
+	WebhookEventArticleUpdated,
+	WebhookEventCommentCreated,
+	WebhookEventUserFollowed,
+}
+
+func IsValidWebhookEvent(event string) bool {
+	for _, item := range WebhookEvents {
+		if item == event {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	WebhookDeliveryPending = "pending"
+	WebhookDeliverySuccess = "success"
+	WebhookDeliveryFailed  = "failed"
+)
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookSignaturePrefix = "sha256="
+)
+
+// Webhook endpoint registered by user (owner),
+// it receive subscribed events about owner articles and profile
+type Webhook struct {
+	ID        ID
+	UserID    ID
+	URL       string
+	Secret    string
+	Events    []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (webhook Webhook) IsSubscribed(event string) bool {
+	for _, item := range webhook.Events {
+		if item == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign payload with webhook secret, receiver compare it with signature header
+func (webhook Webhook) Sign(payload []byte) string {
+	return SignWebhookPayload(webhook.Secret, payload)
+}
+
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return WebhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewWebhook validate url and events, secret is generated
+func NewWebhook(arg Webhook) (Webhook, error) {
+	validator := exception.Validation()
+	now := time.Now()
+
+	webhook := Webhook{
+		ID:        NewID(),
+		UserID:    arg.UserID,
+		URL:       arg.URL,
+		Events:    []string{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := util.ValidateHTTPURL(arg.URL); err != nil {
+		validator.AddError("url", "is not valid url")
+	}
+	if len(arg.Events) == 0 {
+		validator.AddError("events", "required")
+	}
+	seen := map[string]bool{}
+	for _, event := range arg.Events {
+		if !IsValidWebhookEvent(event) {
+			validator.AddError("events", fmt.Sprintf("%s is not supported", event))
+			continue
+		}
+		if !seen[event] {
+			seen[event] = true
+			webhook.Events = append(webhook.Events, event)
+		}
+	}
+	if validator.HasError() {
+		return webhook, validator
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return webhook, exception.Into(err)
+	}
+	webhook.Secret = hex.EncodeToString(secret)
+
+	return webhook, nil
+}
+
+// WebhookDelivery attempt to send event payload to webhook
+type WebhookDelivery struct {
+	ID           ID
+	WebhookID    ID
+	EventID      ID // outbox event, one delivery per webhook
+	Event        string
+	Payload      string
+	Status       string
+	Attempts     int
+	ResponseCode int    // last attempt http status, zero when request failed
+	Error        string // last attempt error
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (delivery WebhookDelivery) IsFailed() bool {
+	return delivery.Status == WebhookDeliveryFailed
+}
+
+func NewWebhookDelivery(arg WebhookDelivery) WebhookDelivery {
+	now := time.Now()
+	id := arg.ID
+	if id == "" {
+		id = NewID()
+	}
+	return WebhookDelivery{
+		ID:        id,
+		WebhookID: arg.WebhookID,
+		EventID:   arg.EventID,
+		Event:     arg.Event,
+		Payload:   arg.Payload,
+		Status:    WebhookDeliveryPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	// echo -n '{"id":1}' | openssl dgst -sha256 -hmac secret
+	signature := SignWebhookPayload("secret", []byte(`{"id":1}`))
+	require.Equal(t, "sha256=03def589620c813f198fd03d7967e292b163ef0435ebf43071ce0e9519763cb7", signature)
+	require.NotEqual(t, signature, SignWebhookPayload("other", []byte(`{"id":1}`)))
+}
+
+func TestNewWebhook(t *testing.T) {
+	testCases := []struct {
+		name   string
+		arg    Webhook
+		errKey string
+	}{
+		{name: "OK", arg: Webhook{URL: "https://example.com/hook", Events: []string{WebhookEventArticleCreated}}},
+		{name: "Invalid URL", arg: Webhook{URL: "example", Events: []string{WebhookEventArticleCreated}}, errKey: "url"},
+		{name: "Not http URL", arg: Webhook{URL: "file:///etc/passwd", Events: []string{WebhookEventArticleCreated}}, errKey: "url"},
+		{name: "No events", arg: Webhook{URL: "https://example.com/hook"}, errKey: "events"},
+		{name: "Unknown event", arg: Webhook{URL: "https://example.com/hook", Events: []string{"article.deleted"}}, errKey: "events"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			webhook, err := NewWebhook(tc.arg)
+			if tc.errKey != "" {
+				fail, ok := err.(*exception.Exception)
+				require.True(t, ok)
+				require.Equal(t, exception.TypeValidation, fail.Type)
+				require.Contains(t, fail.Errors, tc.errKey)
+				return
+			}
+			require.Nil(t, err)
+			require.NotEmpty(t, webhook.ID)
+			require.Len(t, webhook.Secret, 64)
+			require.Equal(t, tc.arg.Events, webhook.Events)
+		})
+	}
+
+	t.Run("Unique events", func(t *testing.T) {
+		webhook, err := NewWebhook(Webhook{
+			URL:    "https://example.com/hook",
+			Events: []string{WebhookEventUserFollowed, WebhookEventUserFollowed},
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{WebhookEventUserFollowed}, webhook.Events)
+	})
+}
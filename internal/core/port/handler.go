@@ -1,5 +1,8 @@
 package port
 
+import "context"
+
 type Server interface {
-	Start() error
+	// Start serve until context done, then shutdown gracefully
+	Start(context.Context) error
 }
@@ -6,8 +6,24 @@ import (
 
 type RepositoryAtomicCallback func(r Repository) error
 
+// RepositoryHealth state of database, migration is zero when database has no versioned migration
+type RepositoryHealth struct {
+	Database         string
+	MigrationVersion uint
+	LatestMigration  uint
+	MigrationDirty   bool // failed migration need manual fix
+}
+
 type Repository interface {
 	Atomic(context.Context, RepositoryAtomicCallback) error
+	// Health ping database and read migration status
+	Health(context.Context) (RepositoryHealth, error)
+	// Close release database connection on shutdown, not to be called inside Atomic
+	Close(context.Context) error
 	User() UserRepository
 	Article() ArticleRepository
+	Notification() NotificationRepository
+	Webhook() WebhookRepository
+	Outbox() OutboxRepository
+	Idempotency() IdempotencyRepository
 }
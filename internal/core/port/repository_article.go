@@ -2,16 +2,51 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/labasubagia/realworld-backend/internal/core/domain"
 )
 
+// article sort order, empty means newest
+const (
+	ArticleSortNewest          = "newest"
+	ArticleSortOldest          = "oldest"
+	ArticleSortMostFavorited   = "most_favorited"
+	ArticleSortMostCommented   = "most_commented"
+	ArticleSortRecentlyUpdated = "recently_updated"
+)
+
+func IsValidArticleSort(sort string) bool {
+	switch sort {
+	case "", ArticleSortNewest, ArticleSortOldest, ArticleSortMostFavorited, ArticleSortMostCommented, ArticleSortRecentlyUpdated:
+		return true
+	}
+	return false
+}
+
+const (
+	CommentSortOldest = "oldest"
+	CommentSortNewest = "newest"
+)
+
+func IsValidCommentSort(sort string) bool {
+	switch sort {
+	case "", CommentSortOldest, CommentSortNewest:
+		return true
+	}
+	return false
+}
+
 type FilterArticlePayload struct {
-	Slugs     []string
-	IDs       []domain.ID
-	AuthorIDs []domain.ID
-	Limit     int
-	Offset    int
+	Slugs         []string
+	IDs           []domain.ID
+	AuthorIDs     []domain.ID
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Sort          string
+	Cursor        domain.Cursor // keyset pagination, only for newest and oldest sort
+	Limit         int
+	Offset        int
 }
 
 type AddTagsPayload struct {
@@ -39,8 +74,25 @@ type FilterFavoritePayload struct {
 }
 
 type FilterCommentPayload struct {
+	IDs        []domain.ID
 	ArticleIDs []domain.ID
 	AuthorIDs  []domain.ID
+	ParentIDs  []domain.ID
+	RootOnly   bool   // only comments without parent
+	Sort       string // oldest by default
+	Cursor     domain.Cursor
+	Limit      int
+}
+
+type FilterCommentRevisionPayload struct {
+	CommentIDs []domain.ID
+}
+
+type FilterMentionPayload struct {
+	UserIDs    []domain.ID
+	ArticleIDs []domain.ID
+	Limit      int
+	Offset     int
 }
 
 type ArticleRepository interface {
@@ -48,6 +100,7 @@ type ArticleRepository interface {
 	UpdateArticle(context.Context, domain.Article) (domain.Article, error)
 	DeleteArticle(context.Context, domain.Article) error
 	FilterArticle(context.Context, FilterArticlePayload) ([]domain.Article, error)
+	CountArticle(context.Context, FilterArticlePayload) (int, error)
 	FindOneArticle(context.Context, FilterArticlePayload) (domain.Article, error)
 
 	FilterTags(context.Context, FilterTagPayload) ([]domain.Tag, error)
@@ -62,6 +115,15 @@ type ArticleRepository interface {
 	FilterFavoriteCount(context.Context, FilterFavoritePayload) ([]domain.ArticleFavoriteCount, error)
 
 	AddComment(context.Context, domain.Comment) (domain.Comment, error)
+	UpdateComment(context.Context, domain.Comment) (domain.Comment, error)
 	DeleteComment(context.Context, domain.Comment) error
 	FilterComment(context.Context, FilterCommentPayload) ([]domain.Comment, error)
+	CountComment(context.Context, FilterCommentPayload) (int, error)
+
+	AddCommentRevision(context.Context, domain.CommentRevision) (domain.CommentRevision, error)
+	FilterCommentRevision(context.Context, FilterCommentRevisionPayload) ([]domain.CommentRevision, error)
+
+	AddMentions(context.Context, []domain.Mention) ([]domain.Mention, error)
+	FilterMention(context.Context, FilterMentionPayload) ([]domain.Mention, error)
+	DeleteMentions(context.Context, []domain.ID) error
 }
@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type FilterIdempotencyPayload struct {
+	IDs           []domain.ID
+	UserIDs       []domain.ID
+	Keys          []string
+	ExpiredBefore time.Time
+}
+
+type IdempotencyRepository interface {
+	// AddIdempotency return false when user already has the key
+	AddIdempotency(context.Context, domain.Idempotency) (bool, error)
+	FilterIdempotency(context.Context, FilterIdempotencyPayload) ([]domain.Idempotency, error)
+	UpdateIdempotency(context.Context, domain.Idempotency) (domain.Idempotency, error)
+	DeleteIdempotency(context.Context, FilterIdempotencyPayload) (int, error)
+}
@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type FilterNotificationPayload struct {
+	IDs        []domain.ID
+	UserIDs    []domain.ID
+	UnreadOnly bool
+	Limit      int
+	Offset     int
+}
+
+type MarkNotificationReadPayload struct {
+	UserID domain.ID
+	IDs    []domain.ID // empty mark all user notifications
+	ReadAt time.Time
+}
+
+type NotificationRepository interface {
+	AddNotifications(context.Context, []domain.Notification) ([]domain.Notification, error)
+	FilterNotification(context.Context, FilterNotificationPayload) ([]domain.Notification, error)
+	CountNotification(context.Context, FilterNotificationPayload) (int, error)
+	MarkNotificationRead(context.Context, MarkNotificationReadPayload) error
+}
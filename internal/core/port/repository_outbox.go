@@ -0,0 +1,32 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type FilterOutboxPayload struct {
+	IDs             []domain.ID
+	DedupeKeys      []string
+	Statuses        []string
+	AvailableBefore time.Time
+	Limit           int
+}
+
+type ClaimOutboxPayload struct {
+	AvailableBefore time.Time
+	LeaseUntil      time.Time // claimed event available again after lease, such as relay crashed
+	Limit           int
+}
+
+type OutboxRepository interface {
+	// AddOutboxEvents skip event which dedupe key already stored
+	AddOutboxEvents(context.Context, []domain.OutboxEvent) error
+	FilterOutboxEvent(context.Context, FilterOutboxPayload) ([]domain.OutboxEvent, error)
+	// ClaimOutboxEvents atomically move available pending events to lease end,
+	// so relay of other instance does not pick the same events
+	ClaimOutboxEvents(context.Context, ClaimOutboxPayload) ([]domain.OutboxEvent, error)
+	UpdateOutboxEvent(context.Context, domain.OutboxEvent) (domain.OutboxEvent, error)
+}
@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type FilterWebhookPayload struct {
+	IDs     []domain.ID
+	UserIDs []domain.ID
+	Events  []string // subscribed to any of events
+}
+
+type FilterWebhookDeliveryPayload struct {
+	IDs        []domain.ID
+	WebhookIDs []domain.ID
+	EventIDs   []domain.ID
+	Limit      int
+	Offset     int
+}
+
+type WebhookRepository interface {
+	CreateWebhook(context.Context, domain.Webhook) (domain.Webhook, error)
+	FilterWebhook(context.Context, FilterWebhookPayload) ([]domain.Webhook, error)
+	DeleteWebhook(context.Context, domain.Webhook) error
+	AddWebhookDelivery(context.Context, domain.WebhookDelivery) (domain.WebhookDelivery, error)
+	UpdateWebhookDelivery(context.Context, domain.WebhookDelivery) (domain.WebhookDelivery, error)
+	FilterWebhookDelivery(context.Context, FilterWebhookDeliveryPayload) ([]domain.WebhookDelivery, error)
+}
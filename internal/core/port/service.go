@@ -6,4 +6,10 @@ type Service interface {
 	TokenMaker() token.Maker
 	User() UserService
 	Article() ArticleService
+	Notification() NotificationService
+	Event() EventService
+	Webhook() WebhookService
+	Outbox() OutboxRelay
+	Idempotency() IdempotencyService
+	Health() HealthService
 }
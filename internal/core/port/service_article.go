@@ -2,10 +2,17 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/labasubagia/realworld-backend/internal/core/domain"
 )
 
+// tag matching mode on article listing
+const (
+	TagModeAny = "any" // article has at least one of the tags
+	TagModeAll = "all" // article has every tag
+)
+
 type CreateArticleTxParams struct {
 	AuthArg AuthParams
 	Article domain.Article
@@ -13,9 +20,10 @@ type CreateArticleTxParams struct {
 }
 
 type UpdateArticleParams struct {
-	AuthArg AuthParams
-	Slug    string
-	Article domain.Article
+	AuthArg         AuthParams
+	Slug            string
+	Article         domain.Article
+	UnmodifiedSince time.Time // fail when article updated after, zero skip check
 }
 
 type DeleteArticleParams struct {
@@ -27,12 +35,23 @@ type ListArticleParams struct {
 	AuthArg        AuthParams
 	IDs            []domain.ID
 	Tags           []string
+	TagMode        string
 	AuthorNames    []string
 	FavoritedNames []string
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	Sort           string
+	Cursor         string // opaque cursor, take precedence over offset
 	Limit          int
 	Offset         int
 }
 
+type ListArticleResult struct {
+	Articles   []domain.Article
+	Count      int // total articles matching the filter, not only the page
+	NextCursor string
+}
+
 type AddFavoriteParams struct {
 	AuthArg AuthParams
 	Slug    string
@@ -55,6 +74,23 @@ type AddCommentParams struct {
 type ListCommentParams struct {
 	AuthArg AuthParams
 	Slug    string
+	Tree    bool // nest replies, cursor and limit apply to root comments
+	Sort    string
+	Cursor  string
+	Limit   int
+}
+
+type ListCommentResult struct {
+	Comments   []domain.Comment
+	Count      int // total comments, root comments only in tree mode
+	NextCursor string
+}
+
+type UpdateCommentParams struct {
+	AuthArg   AuthParams
+	Slug      string
+	CommentID domain.ID
+	Comment   domain.Comment
 }
 
 type DeleteCommentParams struct {
@@ -63,17 +99,33 @@ type DeleteCommentParams struct {
 	CommentID domain.ID
 }
 
+type ListCommentRevisionParams struct {
+	AuthArg   AuthParams
+	Slug      string
+	CommentID domain.ID
+}
+
+type ListMentionParams struct {
+	AuthArg AuthParams
+	Limit   int
+	Offset  int
+}
+
 type ArticleService interface {
 	Create(context.Context, CreateArticleTxParams) (domain.Article, error)
 	Update(context.Context, UpdateArticleParams) (domain.Article, error)
 	Delete(context.Context, DeleteArticleParams) error
-	List(context.Context, ListArticleParams) ([]domain.Article, error)
-	Feed(context.Context, ListArticleParams) ([]domain.Article, error)
+	List(context.Context, ListArticleParams) (ListArticleResult, error)
+	Feed(context.Context, ListArticleParams) (ListArticleResult, error)
 	Get(context.Context, GetArticleParams) (domain.Article, error)
 
 	AddComment(context.Context, AddCommentParams) (domain.Comment, error)
-	ListComments(context.Context, ListCommentParams) ([]domain.Comment, error)
+	ListComments(context.Context, ListCommentParams) (ListCommentResult, error)
+	UpdateComment(context.Context, UpdateCommentParams) (domain.Comment, error)
 	DeleteComment(context.Context, DeleteCommentParams) error
+	ListCommentRevisions(context.Context, ListCommentRevisionParams) ([]domain.CommentRevision, error)
+
+	ListMentions(context.Context, ListMentionParams) ([]domain.Mention, error)
 
 	AddFavorite(context.Context, AddFavoriteParams) (domain.Article, error)
 	RemoveFavorite(context.Context, RemoveFavoriteParams) (domain.Article, error)
@@ -0,0 +1,28 @@
+package port
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type SubscribeEventParams struct {
+	AuthArg      AuthParams
+	ArticleSlugs []string // articles currently viewed, receive their comments
+	LastEventID  string   // resume after this event
+}
+
+type SubscribeCommentParams struct {
+	Slug        string
+	LastEventID string // resume after this event
+}
+
+type EventSubscription interface {
+	Events() <-chan domain.Event
+	Close()
+}
+
+type EventService interface {
+	Subscribe(context.Context, SubscribeEventParams) (EventSubscription, error)
+	SubscribeComments(context.Context, SubscribeCommentParams) (EventSubscription, error)
+}
@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+)
+
+type HealthService interface {
+	// Ready check database is reachable and fully migrated
+	Ready(context.Context) (RepositoryHealth, error)
+}
@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type BeginIdempotencyParams struct {
+	AuthArg     AuthParams
+	Key         string
+	RequestHash string
+}
+
+type IdempotencyService interface {
+	// Begin reserve key for request, return true with stored response when it should be replayed
+	Begin(context.Context, BeginIdempotencyParams) (domain.Idempotency, bool, error)
+	// Complete store response for replay until key expired
+	Complete(context.Context, domain.Idempotency) error
+	// Release remove reserved key, so failed request can be retried
+	Release(context.Context, domain.Idempotency) error
+	// Purge remove expired keys, return number of removed keys
+	Purge(context.Context) (int, error)
+}
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type ListNotificationParams struct {
+	AuthArg    AuthParams
+	UnreadOnly bool
+	Limit      int
+	Offset     int
+}
+
+type ListNotificationResult struct {
+	Notifications []domain.Notification
+	Count         int
+	UnreadCount   int
+}
+
+type MarkNotificationReadParams struct {
+	AuthArg AuthParams
+	IDs     []domain.ID
+}
+
+type NotificationService interface {
+	List(context.Context, ListNotificationParams) (ListNotificationResult, error)
+	MarkRead(context.Context, MarkNotificationReadParams) error
+	MarkAllRead(context.Context, AuthParams) error
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+// OutboxHandler consume outbox event, event is retried when return error
+// so handler should be idempotent
+type OutboxHandler func(context.Context, domain.OutboxEvent) error
+
+type OutboxRelay interface {
+	Register(eventType string, handler OutboxHandler)
+	// Process dispatch available pending events once, return number of processed events
+	Process(context.Context) (int, error)
+	// Run process events every poll interval until context done
+	Run(context.Context)
+}
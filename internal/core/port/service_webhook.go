@@ -0,0 +1,38 @@
+package port
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+type CreateWebhookParams struct {
+	AuthArg AuthParams
+	Webhook domain.Webhook
+}
+
+type DeleteWebhookParams struct {
+	AuthArg   AuthParams
+	WebhookID domain.ID
+}
+
+type ListWebhookDeliveryParams struct {
+	AuthArg   AuthParams
+	WebhookID domain.ID
+	Limit     int
+	Offset    int
+}
+
+type RedeliverWebhookParams struct {
+	AuthArg    AuthParams
+	WebhookID  domain.ID
+	DeliveryID domain.ID
+}
+
+type WebhookService interface {
+	Create(context.Context, CreateWebhookParams) (domain.Webhook, error)
+	List(context.Context, AuthParams) ([]domain.Webhook, error)
+	Delete(context.Context, DeleteWebhookParams) error
+	ListDeliveries(context.Context, ListWebhookDeliveryParams) ([]domain.WebhookDelivery, error)
+	Redeliver(context.Context, RedeliverWebhookParams) (domain.WebhookDelivery, error)
+}
@@ -2,12 +2,15 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/labasubagia/realworld-backend/internal/core/domain"
 	"github.com/labasubagia/realworld-backend/internal/core/port"
 	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/labasubagia/realworld-backend/internal/core/util/metrics"
 )
 
 type articleService struct {
@@ -25,6 +28,7 @@ func (s *articleService) Create(ctx context.Context, arg port.CreateArticleTxPar
 		return domain.Article{}, exception.New(exception.TypePermissionDenied, "token payload not provided", nil)
 	}
 
+	var notifications []domain.Notification
 	err = s.property.repo.Atomic(ctx, func(r port.Repository) error {
 
 		arg.Article.AuthorID = arg.AuthArg.Payload.UserID
@@ -36,36 +40,78 @@ func (s *articleService) Create(ctx context.Context, arg port.CreateArticleTxPar
 			return exception.Into(err)
 		}
 
-		// return when no tags
-		if len(arg.Tags) == 0 {
-			return nil
-		}
-
-		// add tags if not exists
-		for i, tag := range arg.Tags {
-			arg.Tags[i] = strings.ToLower(tag)
-		}
-		tags, err := r.Article().AddTags(ctx, port.AddTagsPayload{Tags: arg.Tags})
+		notifications, err = s.syncMentions(ctx, r, domain.Mention{AuthorID: article.AuthorID, ArticleID: article.ID}, article.Body)
 		if err != nil {
 			return exception.Into(err)
 		}
 
-		// assign tags
-		tagIDs := []domain.ID{}
-		for _, tag := range tags {
-			tagIDs = append(tagIDs, tag.ID)
+		if len(arg.Tags) > 0 {
+			// add tags if not exists
+			for i, tag := range arg.Tags {
+				arg.Tags[i] = strings.ToLower(tag)
+			}
+			tags, err := r.Article().AddTags(ctx, port.AddTagsPayload{Tags: arg.Tags})
+			if err != nil {
+				return exception.Into(err)
+			}
+
+			// assign tags
+			tagIDs := []domain.ID{}
+			for _, tag := range tags {
+				tagIDs = append(tagIDs, tag.ID)
+			}
+			_, err = r.Article().AssignArticleTags(ctx, port.AssignTagPayload{ArticleID: article.ID, TagIDs: tagIDs})
+			if err != nil {
+				return exception.Into(err)
+			}
 		}
-		_, err = r.Article().AssignArticleTags(ctx, port.AssignTagPayload{ArticleID: article.ID, TagIDs: tagIDs})
+
+		snapshot, err := articleSnapshot(ctx, r, article)
 		if err != nil {
 			return exception.Into(err)
 		}
-		return nil
+		dedupeKey := domain.OutboxDedupeKey(domain.DomainEventArticleCreated, article.ID)
+		return s.property.addOutbox(ctx, r, domain.DomainEventArticleCreated, dedupeKey, article.AuthorID, snapshot)
 	})
 	if err != nil {
 		return domain.Article{}, exception.Into(err)
 	}
+	metrics.ArticleCreated()
+	s.property.publishNotifications(notifications...)
 
-	return s.infoArticle(ctx, GetArticleInfoParams{authArg: arg.AuthArg, article: article})
+	article, err = s.infoArticle(ctx, GetArticleInfoParams{authArg: arg.AuthArg, article: article})
+	if err != nil {
+		return domain.Article{}, exception.Into(err)
+	}
+	s.publishArticle(ctx, article)
+
+	return article, nil
+}
+
+// publishArticle send new article to author followers feed,
+// failure is only logged since article already created
+func (s *articleService) publishArticle(ctx context.Context, article domain.Article) {
+	follows, err := s.property.repo.User().FilterFollow(ctx, port.FilterUserFollowPayload{
+		FolloweeIDs: []domain.ID{article.AuthorID},
+	})
+	if err != nil {
+		port.GetCtxSubLogger(ctx, s.property.logger).Error().Err(err).Msg("failed to get followers for article event")
+		return
+	}
+	if len(follows) == 0 {
+		return
+	}
+	followerIDs := []domain.ID{}
+	for _, follow := range follows {
+		followerIDs = append(followerIDs, follow.FollowerID)
+	}
+	article.Author.IsFollowed = true
+	s.property.publish(domain.Event{
+		Type:      domain.EventTypeArticle,
+		UserIDs:   followerIDs,
+		ArticleID: article.ID,
+		Data:      article,
+	})
 }
 
 func (s *articleService) Update(ctx context.Context, arg port.UpdateArticleParams) (domain.Article, error) {
@@ -80,17 +126,57 @@ func (s *articleService) Update(ctx context.Context, arg port.UpdateArticleParam
 	if err != nil {
 		return domain.Article{}, exception.Into(err)
 	}
+	// client read older version, repository check again on write
+	if arg.Article.Version > 0 && arg.Article.Version != current.Version {
+		return domain.Article{}, exception.New(exception.TypeConflict, "article has been modified", nil)
+	}
 
 	current.Title = arg.Article.Title
 	current.Description = arg.Article.Description
 	current.Body = arg.Article.Body
+	current.UpdatedAt = time.Now()
+
+	var updated domain.Article
+	var notifications []domain.Notification
+	err = s.property.repo.Atomic(ctx, func(r port.Repository) error {
+		// check again inside transaction, other editor may update after read
+		if !arg.UnmodifiedSince.IsZero() {
+			latest, err := r.Article().FindOneArticle(ctx, port.FilterArticlePayload{IDs: []domain.ID{current.ID}})
+			if err != nil {
+				return exception.Into(err)
+			}
+			if !latest.UpdatedAt.Equal(arg.UnmodifiedSince) {
+				return exception.New(exception.TypePreconditionFailed, "article has been modified", nil)
+			}
+		}
+
+		updated, err = r.Article().UpdateArticle(ctx, current)
+		if err != nil {
+			return exception.Into(err)
+		}
+		notifications, err = s.syncMentions(ctx, r, domain.Mention{AuthorID: updated.AuthorID, ArticleID: updated.ID}, updated.Body)
+		if err != nil {
+			return exception.Into(err)
+		}
+
+		snapshot, err := articleSnapshot(ctx, r, updated)
+		if err != nil {
+			return exception.Into(err)
+		}
+		dedupeKey := domain.OutboxDedupeKey(domain.DomainEventArticleUpdated, updated.ID, updated.UpdatedAt.UnixNano())
+		return s.property.addOutbox(ctx, r, domain.DomainEventArticleUpdated, dedupeKey, updated.AuthorID, snapshot)
+	})
+	if err != nil {
+		return domain.Article{}, exception.Into(err)
+	}
+	s.property.publishNotifications(notifications...)
 
-	updated, err := s.property.repo.Article().UpdateArticle(ctx, current)
+	updated, err = s.infoArticle(ctx, GetArticleInfoParams{authArg: arg.AuthArg, article: updated})
 	if err != nil {
 		return domain.Article{}, exception.Into(err)
 	}
 
-	return s.infoArticle(ctx, GetArticleInfoParams{authArg: arg.AuthArg, article: updated})
+	return updated, nil
 }
 
 func (s *articleService) Delete(ctx context.Context, arg port.DeleteArticleParams) error {
@@ -133,13 +219,27 @@ func (s *articleService) AddFavorite(ctx context.Context, arg port.AddFavoritePa
 	}
 
 	if len(favorites) == 0 {
-		_, err := s.property.repo.Article().AddFavorite(ctx, domain.ArticleFavorite{
-			ArticleID: article.ID,
-			UserID:    arg.AuthArg.Payload.UserID,
+		var notifications []domain.Notification
+		err = s.property.repo.Atomic(ctx, func(r port.Repository) error {
+			_, err := r.Article().AddFavorite(ctx, domain.ArticleFavorite{
+				ArticleID: article.ID,
+				UserID:    arg.AuthArg.Payload.UserID,
+			})
+			if err != nil {
+				return exception.Into(err)
+			}
+			notifications, err = s.property.notify(ctx, r, domain.Notification{
+				UserID:    article.AuthorID,
+				ActorID:   arg.AuthArg.Payload.UserID,
+				Type:      domain.NotificationTypeFavorite,
+				ArticleID: article.ID,
+			})
+			return err
 		})
 		if err != nil {
 			return domain.Article{}, exception.Into(err)
 		}
+		s.property.publishNotifications(notifications...)
 	}
 
 	return s.infoArticle(ctx, GetArticleInfoParams{authArg: arg.AuthArg, article: article})
@@ -156,9 +256,13 @@ func (s *articleService) RemoveFavorite(ctx context.Context, arg port.RemoveFavo
 		return domain.Article{}, exception.Into(err)
 	}
 
-	_, err = s.property.repo.Article().RemoveFavorite(ctx, domain.ArticleFavorite{
-		ArticleID: article.ID,
-		UserID:    arg.AuthArg.Payload.UserID,
+	// favorite and counter of article removed together
+	err = s.property.repo.Atomic(ctx, func(r port.Repository) error {
+		_, err := r.Article().RemoveFavorite(ctx, domain.ArticleFavorite{
+			ArticleID: article.ID,
+			UserID:    arg.AuthArg.Payload.UserID,
+		})
+		return err
 	})
 	if err != nil {
 		return domain.Article{}, exception.Into(err)
@@ -185,7 +289,29 @@ func (s *articleService) Get(ctx context.Context, arg port.GetArticleParams) (do
 	return articleInfos[0], nil
 }
 
-func (s *articleService) List(ctx context.Context, arg port.ListArticleParams) (result []domain.Article, err error) {
+func (s *articleService) List(ctx context.Context, arg port.ListArticleParams) (result port.ListArticleResult, err error) {
+
+	tagMode := arg.TagMode
+	if tagMode == "" {
+		tagMode = port.TagModeAny
+	}
+	if tagMode != port.TagModeAny && tagMode != port.TagModeAll {
+		return port.ListArticleResult{}, exception.Validation().AddError("tag_mode", "must be any or all")
+	}
+	if !port.IsValidArticleSort(arg.Sort) {
+		return port.ListArticleResult{}, exception.Validation().AddError("sort", "invalid sort")
+	}
+	cursor, err := parseArticleCursor(arg.Cursor, arg.Sort)
+	if err != nil {
+		return port.ListArticleResult{}, exception.Into(err)
+	}
+
+	// every filter narrows down article ids
+	// nil means the filter is not applied
+	var filterIDs []domain.ID
+	if len(arg.IDs) > 0 {
+		filterIDs = arg.IDs
+	}
 
 	// filter authors
 	authorIDs := []domain.ID{}
@@ -194,11 +320,11 @@ func (s *articleService) List(ctx context.Context, arg port.ListArticleParams) (
 			Usernames: arg.AuthorNames,
 		})
 		if err != nil {
-			return []domain.Article{}, exception.Into(err)
+			return port.ListArticleResult{}, exception.Into(err)
 		}
 
 		if len(authors) == 0 {
-			return []domain.Article{}, nil
+			return port.ListArticleResult{}, nil
 		}
 
 		for _, author := range authors {
@@ -207,17 +333,21 @@ func (s *articleService) List(ctx context.Context, arg port.ListArticleParams) (
 	}
 
 	// filter tags
-	taggedArticleIDs := []domain.ID{}
 	if len(arg.Tags) > 0 {
 
 		// find tags
 		tags, err := s.property.repo.Article().FilterTags(ctx, port.FilterTagPayload{Names: arg.Tags})
 		if err != nil {
-			return []domain.Article{}, exception.Into(err)
+			return port.ListArticleResult{}, exception.Into(err)
 		}
 
 		if len(tags) == 0 {
-			return []domain.Article{}, nil
+			return port.ListArticleResult{}, nil
+		}
+
+		// every requested tag must exist
+		if tagMode == port.TagModeAll && len(tags) < len(uniqueStrings(arg.Tags)) {
+			return port.ListArticleResult{}, nil
 		}
 
 		tagIDs := []domain.ID{}
@@ -230,29 +360,48 @@ func (s *articleService) List(ctx context.Context, arg port.ListArticleParams) (
 			TagIDs: tagIDs,
 		})
 		if err != nil {
-			return []domain.Article{}, exception.Into(err)
+			return port.ListArticleResult{}, exception.Into(err)
 		}
 		if len(articleTags) == 0 {
-			return []domain.Article{}, nil
+			return port.ListArticleResult{}, nil
 		}
 
+		// count matched tags for each article
+		taggedArticleIDs := []domain.ID{}
+		tagCountMap := map[domain.ID]int{}
 		for _, articleTag := range articleTags {
-			taggedArticleIDs = append(taggedArticleIDs, articleTag.ArticleID)
+			if tagCountMap[articleTag.ArticleID] == 0 {
+				taggedArticleIDs = append(taggedArticleIDs, articleTag.ArticleID)
+			}
+			tagCountMap[articleTag.ArticleID]++
+		}
+		if tagMode == port.TagModeAll {
+			allTaggedArticleIDs := []domain.ID{}
+			for _, articleID := range taggedArticleIDs {
+				if tagCountMap[articleID] == len(tagIDs) {
+					allTaggedArticleIDs = append(allTaggedArticleIDs, articleID)
+				}
+			}
+			taggedArticleIDs = allTaggedArticleIDs
+		}
+
+		filterIDs = intersectIDs(filterIDs, taggedArticleIDs)
+		if len(filterIDs) == 0 {
+			return port.ListArticleResult{}, nil
 		}
 	}
 
 	// filter favorites by users
-	favoritedArticleIDs := []domain.ID{}
 	if len(arg.FavoritedNames) > 0 {
 		// find users
 		users, err := s.property.repo.User().FilterUser(ctx, port.FilterUserPayload{
 			Usernames: arg.FavoritedNames,
 		})
 		if err != nil {
-			return []domain.Article{}, exception.Into(err)
+			return port.ListArticleResult{}, exception.Into(err)
 		}
 		if len(users) == 0 {
-			return []domain.Article{}, nil
+			return port.ListArticleResult{}, nil
 		}
 		userIDs := []domain.ID{}
 		for _, user := range users {
@@ -264,53 +413,53 @@ func (s *articleService) List(ctx context.Context, arg port.ListArticleParams) (
 			UserIDs: userIDs,
 		})
 		if err != nil {
-			return []domain.Article{}, exception.Into(err)
+			return port.ListArticleResult{}, exception.Into(err)
 		}
 		if len(favorites) == 0 {
-			return []domain.Article{}, nil
+			return port.ListArticleResult{}, nil
 		}
 
+		favoritedArticleIDs := []domain.ID{}
 		for _, favorite := range favorites {
 			favoritedArticleIDs = append(favoritedArticleIDs, favorite.ArticleID)
 		}
-	}
 
-	// Get articles
-	articles, err := s.property.repo.Article().FilterArticle(ctx, port.FilterArticlePayload{
-		IDs:       append(arg.IDs, append(taggedArticleIDs, favoritedArticleIDs...)...),
-		AuthorIDs: authorIDs,
-		Limit:     arg.Limit,
-		Offset:    arg.Offset,
-	})
-	if err != nil {
-		return []domain.Article{}, exception.Into(err)
+		filterIDs = intersectIDs(filterIDs, favoritedArticleIDs)
+		if len(filterIDs) == 0 {
+			return port.ListArticleResult{}, nil
+		}
 	}
 
-	result, err = s.listInfoArticles(ctx, GetListArticleInfoParams{
-		authArg:  arg.AuthArg,
-		articles: articles,
+	// Get articles
+	return s.pageArticles(ctx, arg, cursor, port.FilterArticlePayload{
+		IDs:           filterIDs,
+		AuthorIDs:     authorIDs,
+		CreatedAfter:  arg.CreatedAfter,
+		CreatedBefore: arg.CreatedBefore,
 	})
-	if err != nil {
-		return []domain.Article{}, exception.Into(err)
-	}
-
-	return result, nil
 }
 
-func (s *articleService) Feed(ctx context.Context, arg port.ListArticleParams) (result []domain.Article, err error) {
+func (s *articleService) Feed(ctx context.Context, arg port.ListArticleParams) (result port.ListArticleResult, err error) {
 
 	if arg.AuthArg.Payload == nil {
-		return result, exception.New(exception.TypePermissionDenied, "authentication required", nil)
+		return port.ListArticleResult{}, exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+	if !port.IsValidArticleSort(arg.Sort) {
+		return port.ListArticleResult{}, exception.Validation().AddError("sort", "invalid sort")
+	}
+	cursor, err := parseArticleCursor(arg.Cursor, arg.Sort)
+	if err != nil {
+		return port.ListArticleResult{}, exception.Into(err)
 	}
 
 	followingAuthors, err := s.property.repo.User().FilterFollow(ctx, port.FilterUserFollowPayload{
 		FollowerIDs: []domain.ID{arg.AuthArg.Payload.UserID},
 	})
 	if err != nil {
-		return []domain.Article{}, exception.Into(err)
+		return port.ListArticleResult{}, exception.Into(err)
 	}
 	if len(followingAuthors) == 0 {
-		return []domain.Article{}, nil
+		return port.ListArticleResult{}, nil
 	}
 
 	authorIDs := []domain.ID{}
@@ -318,21 +467,46 @@ func (s *articleService) Feed(ctx context.Context, arg port.ListArticleParams) (
 		authorIDs = append(authorIDs, author.FolloweeID)
 	}
 
-	articles, err := s.property.repo.Article().FilterArticle(ctx, port.FilterArticlePayload{
+	return s.pageArticles(ctx, arg, cursor, port.FilterArticlePayload{
 		AuthorIDs: authorIDs,
-		Limit:     arg.Limit,
-		Offset:    arg.Offset,
 	})
+}
+
+// pageArticles get a page of articles matching filter with the total count,
+// extra fetched article is trimmed into next cursor
+func (s *articleService) pageArticles(ctx context.Context, arg port.ListArticleParams, cursor domain.Cursor, filter port.FilterArticlePayload) (port.ListArticleResult, error) {
+	count, err := s.property.repo.Article().CountArticle(ctx, filter)
 	if err != nil {
-		return []domain.Article{}, exception.Into(err)
+		return port.ListArticleResult{}, exception.Into(err)
+	}
+	result := port.ListArticleResult{Count: count}
+	if count == 0 {
+		return result, nil
+	}
+
+	filter.Sort = arg.Sort
+	filter.Cursor = cursor
+	filter.Limit = pageFetchLimit(arg.Limit)
+	filter.Offset = pageOffset(arg.Offset, cursor)
+	articles, err := s.property.repo.Article().FilterArticle(ctx, filter)
+	if err != nil {
+		return port.ListArticleResult{}, exception.Into(err)
 	}
 
-	result, err = s.listInfoArticles(ctx, GetListArticleInfoParams{
+	if arg.Limit > 0 && len(articles) > arg.Limit {
+		articles = articles[:arg.Limit]
+		if isCursorArticleSort(arg.Sort) {
+			last := articles[len(articles)-1]
+			result.NextCursor = domain.NewCursor(last.CreatedAt, last.ID).String()
+		}
+	}
+
+	result.Articles, err = s.listInfoArticles(ctx, GetListArticleInfoParams{
 		authArg:  arg.AuthArg,
 		articles: articles,
 	})
 	if err != nil {
-		return []domain.Article{}, exception.Into(err)
+		return port.ListArticleResult{}, exception.Into(err)
 	}
 
 	return result, nil
@@ -487,14 +661,78 @@ func (s *articleService) AddComment(ctx context.Context, arg port.AddCommentPara
 		return domain.Comment{}, exception.Into(err)
 	}
 
-	comment, err := s.property.repo.Article().AddComment(ctx, domain.NewComment(domain.Comment{
-		ArticleID: article.ID,
-		AuthorID:  arg.AuthArg.Payload.UserID,
-		Body:      arg.Comment.Body,
-	}))
+	// reply is one level deeper than its parent
+	depth := 0
+	var parent domain.Comment
+	if arg.Comment.ParentID != "" {
+		parents, err := s.property.repo.Article().FilterComment(ctx, port.FilterCommentPayload{
+			IDs:        []domain.ID{arg.Comment.ParentID},
+			ArticleIDs: []domain.ID{article.ID},
+		})
+		if err != nil {
+			return domain.Comment{}, exception.Into(err)
+		}
+		if len(parents) == 0 {
+			return domain.Comment{}, exception.Validation().AddError("parent_id", "comment not found")
+		}
+		parent = parents[0]
+		if parent.IsDeleted {
+			return domain.Comment{}, exception.Validation().AddError("parent_id", "comment is deleted")
+		}
+		if parent.Depth >= domain.CommentMaxDepth {
+			return domain.Comment{}, exception.Validation().AddError("parent_id", fmt.Sprintf("max reply depth is %d", domain.CommentMaxDepth))
+		}
+		depth = parent.Depth + 1
+	}
+
+	var comment domain.Comment
+	var notifications []domain.Notification
+	err = s.property.repo.Atomic(ctx, func(r port.Repository) error {
+		comment, err = r.Article().AddComment(ctx, domain.NewComment(domain.Comment{
+			ArticleID: article.ID,
+			AuthorID:  arg.AuthArg.Payload.UserID,
+			ParentID:  arg.Comment.ParentID,
+			Depth:     depth,
+			Body:      arg.Comment.Body,
+		}))
+		if err != nil {
+			return exception.Into(err)
+		}
+		notifications, err = s.syncMentions(ctx, r, domain.Mention{AuthorID: comment.AuthorID, ArticleID: article.ID, CommentID: comment.ID}, comment.Body)
+		if err != nil {
+			return exception.Into(err)
+		}
+
+		// parent author get reply instead of comment when also article author
+		notification := domain.Notification{
+			ActorID:   comment.AuthorID,
+			ArticleID: article.ID,
+			CommentID: comment.ID,
+		}
+		reply, onArticle := notification, notification
+		reply.UserID, reply.Type = parent.AuthorID, domain.NotificationTypeReply
+		onArticle.UserID, onArticle.Type = article.AuthorID, domain.NotificationTypeComment
+		if parent.AuthorID == article.AuthorID {
+			onArticle.UserID = ""
+		}
+		notified, err := s.property.notify(ctx, r, reply, onArticle)
+		if err != nil {
+			return exception.Into(err)
+		}
+		notifications = append(notifications, notified...)
+
+		author, err := r.User().FindOne(ctx, port.FilterUserPayload{IDs: []domain.ID{comment.AuthorID}})
+		if err != nil {
+			return exception.Into(err)
+		}
+		dedupeKey := domain.OutboxDedupeKey(domain.DomainEventCommentCreated, comment.ID)
+		return s.property.addOutbox(ctx, r, domain.DomainEventCommentCreated, dedupeKey, article.AuthorID, asEventComment(article, comment, author))
+	})
 	if err != nil {
 		return domain.Comment{}, exception.Into(err)
 	}
+	metrics.CommentCreated()
+	s.property.publishNotifications(notifications...)
 
 	// Get decorator info
 	comments, err := s.listInfoComments(ctx, GetCommentInfo{
@@ -508,37 +746,233 @@ func (s *articleService) AddComment(ctx context.Context, arg port.AddCommentPara
 		return domain.Comment{}, exception.New(exception.TypeNotFound, "comment not found", nil)
 	}
 
+	s.property.publish(domain.Event{
+		Type:      domain.EventTypeComment,
+		ArticleID: article.ID,
+		Data:      comments[0],
+	})
+
 	return comments[0], nil
 }
 
-func (s *articleService) ListComments(ctx context.Context, arg port.ListCommentParams) (result []domain.Comment, err error) {
+func (s *articleService) ListComments(ctx context.Context, arg port.ListCommentParams) (result port.ListCommentResult, err error) {
+
+	if !port.IsValidCommentSort(arg.Sort) {
+		return port.ListCommentResult{}, exception.Validation().AddError("sort", "must be oldest or newest")
+	}
+
+	cursor, err := domain.ParseCursor(arg.Cursor)
+	if err != nil {
+		return port.ListCommentResult{}, exception.Validation().AddError("cursor", err.Error())
+	}
 
 	article, err := s.property.repo.Article().FindOneArticle(ctx, port.FilterArticlePayload{
 		Slugs: []string{arg.Slug},
 	})
 	if err != nil {
-		return []domain.Comment{}, exception.Into(err)
+		return port.ListCommentResult{}, exception.Into(err)
 	}
 
-	comments, err := s.property.repo.Article().FilterComment(ctx, port.FilterCommentPayload{
+	// in tree mode page only root comments
+	filter := port.FilterCommentPayload{
 		ArticleIDs: []domain.ID{article.ID},
-	})
+		RootOnly:   arg.Tree,
+	}
+	result.Count, err = s.property.repo.Article().CountComment(ctx, filter)
 	if err != nil {
-		return []domain.Comment{}, exception.Into(err)
+		return port.ListCommentResult{}, exception.Into(err)
+	}
+	if result.Count == 0 {
+		result.Comments = []domain.Comment{}
+		return result, nil
+	}
+
+	filter.Sort = arg.Sort
+	filter.Cursor = cursor
+	filter.Limit = pageFetchLimit(arg.Limit)
+	comments, err := s.property.repo.Article().FilterComment(ctx, filter)
+	if err != nil {
+		return port.ListCommentResult{}, exception.Into(err)
+	}
+	if arg.Limit > 0 && len(comments) > arg.Limit {
+		comments = comments[:arg.Limit]
+		last := comments[len(comments)-1]
+		result.NextCursor = domain.NewCursor(last.CreatedAt, last.ID).String()
+	}
+
+	// load replies of page roots level by level
+	if arg.Tree {
+		parentIDs := []domain.ID{}
+		for _, comment := range comments {
+			parentIDs = append(parentIDs, comment.ID)
+		}
+		for depth := 1; depth <= domain.CommentMaxDepth && len(parentIDs) > 0; depth++ {
+			replies, err := s.property.repo.Article().FilterComment(ctx, port.FilterCommentPayload{
+				ArticleIDs: []domain.ID{article.ID},
+				ParentIDs:  parentIDs,
+			})
+			if err != nil {
+				return port.ListCommentResult{}, exception.Into(err)
+			}
+			parentIDs = []domain.ID{}
+			for _, reply := range replies {
+				parentIDs = append(parentIDs, reply.ID)
+			}
+			comments = append(comments, replies...)
+		}
 	}
 
 	// Get decorator info
-	result, err = s.listInfoComments(ctx, GetCommentInfo{
+	comments, err = s.listInfoComments(ctx, GetCommentInfo{
 		authArg:  arg.AuthArg,
 		comments: comments,
 	})
 	if err != nil {
-		return []domain.Comment{}, exception.Into(err)
+		return port.ListCommentResult{}, exception.Into(err)
+	}
+
+	result.Comments = comments
+	if arg.Tree {
+		result.Comments = buildCommentTree(comments)
 	}
 
 	return result, nil
 }
 
+// buildCommentTree nest replies into their parent, keep comments order
+func buildCommentTree(comments []domain.Comment) []domain.Comment {
+	exists := map[domain.ID]bool{}
+	children := map[domain.ID][]domain.Comment{}
+	for _, comment := range comments {
+		exists[comment.ID] = true
+	}
+	roots := []domain.Comment{}
+	for _, comment := range comments {
+		if comment.ParentID == "" || !exists[comment.ParentID] {
+			roots = append(roots, comment)
+			continue
+		}
+		children[comment.ParentID] = append(children[comment.ParentID], comment)
+	}
+
+	var attach func(comment domain.Comment) domain.Comment
+	attach = func(comment domain.Comment) domain.Comment {
+		comment.Replies = []domain.Comment{}
+		for _, child := range children[comment.ID] {
+			comment.Replies = append(comment.Replies, attach(child))
+		}
+		return comment
+	}
+	for i, root := range roots {
+		roots[i] = attach(root)
+	}
+	return roots
+}
+
+func (s *articleService) UpdateComment(ctx context.Context, arg port.UpdateCommentParams) (domain.Comment, error) {
+	if arg.AuthArg.Payload == nil {
+		return domain.Comment{}, exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+
+	article, err := s.property.repo.Article().FindOneArticle(ctx, port.FilterArticlePayload{
+		Slugs: []string{arg.Slug},
+	})
+	if err != nil {
+		return domain.Comment{}, exception.Into(err)
+	}
+
+	comments, err := s.property.repo.Article().FilterComment(ctx, port.FilterCommentPayload{
+		IDs:        []domain.ID{arg.CommentID},
+		ArticleIDs: []domain.ID{article.ID},
+	})
+	if err != nil {
+		return domain.Comment{}, exception.Into(err)
+	}
+	if len(comments) == 0 {
+		return domain.Comment{}, exception.New(exception.TypeNotFound, "comment not found", nil)
+	}
+	comment := comments[0]
+	if comment.AuthorID != arg.AuthArg.Payload.UserID {
+		return domain.Comment{}, exception.New(exception.TypePermissionDenied, "only author can edit comment", nil)
+	}
+	if comment.IsDeleted {
+		return domain.Comment{}, exception.Validation().AddError("comment", "comment is deleted")
+	}
+	if arg.Comment.Body == "" {
+		return domain.Comment{}, exception.Validation().AddError("body", "required")
+	}
+
+	// keep previous body as revision
+	if arg.Comment.Body != comment.Body {
+		var notifications []domain.Notification
+		err = s.property.repo.Atomic(ctx, func(r port.Repository) error {
+			revision := comment.Edit(arg.Comment.Body)
+			if _, err := r.Article().AddCommentRevision(ctx, revision); err != nil {
+				return exception.Into(err)
+			}
+			if comment, err = r.Article().UpdateComment(ctx, comment); err != nil {
+				return exception.Into(err)
+			}
+			notifications, err = s.syncMentions(ctx, r, domain.Mention{AuthorID: comment.AuthorID, ArticleID: article.ID, CommentID: comment.ID}, comment.Body)
+			return err
+		})
+		if err != nil {
+			return domain.Comment{}, exception.Into(err)
+		}
+		s.property.publishNotifications(notifications...)
+	}
+
+	// Get decorator info
+	comments, err = s.listInfoComments(ctx, GetCommentInfo{
+		authArg:  arg.AuthArg,
+		comments: []domain.Comment{comment},
+	})
+	if err != nil {
+		return domain.Comment{}, exception.Into(err)
+	}
+	if len(comments) == 0 {
+		return domain.Comment{}, exception.New(exception.TypeNotFound, "comment not found", nil)
+	}
+
+	return comments[0], nil
+}
+
+// ListCommentRevisions list previous bodies of comment oldest first, only author can see them
+func (s *articleService) ListCommentRevisions(ctx context.Context, arg port.ListCommentRevisionParams) ([]domain.CommentRevision, error) {
+	if arg.AuthArg.Payload == nil {
+		return []domain.CommentRevision{}, exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+
+	article, err := s.property.repo.Article().FindOneArticle(ctx, port.FilterArticlePayload{
+		Slugs: []string{arg.Slug},
+	})
+	if err != nil {
+		return []domain.CommentRevision{}, exception.Into(err)
+	}
+
+	comments, err := s.property.repo.Article().FilterComment(ctx, port.FilterCommentPayload{
+		IDs:        []domain.ID{arg.CommentID},
+		ArticleIDs: []domain.ID{article.ID},
+	})
+	if err != nil {
+		return []domain.CommentRevision{}, exception.Into(err)
+	}
+	if len(comments) == 0 {
+		return []domain.CommentRevision{}, exception.New(exception.TypeNotFound, "comment not found", nil)
+	}
+	if comments[0].AuthorID != arg.AuthArg.Payload.UserID {
+		return []domain.CommentRevision{}, exception.New(exception.TypePermissionDenied, "only author can view comment revisions", nil)
+	}
+
+	revisions, err := s.property.repo.Article().FilterCommentRevision(ctx, port.FilterCommentRevisionPayload{
+		CommentIDs: []domain.ID{arg.CommentID},
+	})
+	if err != nil {
+		return []domain.CommentRevision{}, exception.Into(err)
+	}
+	return revisions, nil
+}
+
 func (s *articleService) DeleteComment(ctx context.Context, arg port.DeleteCommentParams) error {
 	if arg.AuthArg.Payload == nil {
 		return exception.New(exception.TypePermissionDenied, "authentication required", nil)
@@ -551,18 +985,204 @@ func (s *articleService) DeleteComment(ctx context.Context, arg port.DeleteComme
 		return exception.Into(err)
 	}
 
-	err = s.property.repo.Article().DeleteComment(ctx, domain.Comment{
-		ArticleID: article.ID,
-		AuthorID:  arg.AuthArg.Payload.UserID,
-		ID:        arg.CommentID,
+	comments, err := s.property.repo.Article().FilterComment(ctx, port.FilterCommentPayload{
+		IDs:        []domain.ID{arg.CommentID},
+		ArticleIDs: []domain.ID{article.ID},
+		AuthorIDs:  []domain.ID{arg.AuthArg.Payload.UserID},
 	})
 	if err != nil {
 		return exception.Into(err)
 	}
+	if len(comments) == 0 {
+		return exception.New(exception.TypeNotFound, "comment not found", nil)
+	}
+
+	deleted := []domain.Comment{}
+	err = s.property.repo.Atomic(ctx, func(r port.Repository) error {
+		deleted = []domain.Comment{}
+		comment := comments[0]
+
+		// comment with replies keep as tombstone
+		replies, err := r.Article().FilterComment(ctx, port.FilterCommentPayload{
+			ParentIDs: []domain.ID{comment.ID},
+			Limit:     1,
+		})
+		if err != nil {
+			return exception.Into(err)
+		}
+		if len(replies) > 0 {
+			if comment.IsDeleted {
+				return nil
+			}
+			comment.Tombstone()
+			if _, err := r.Article().UpdateComment(ctx, comment); err != nil {
+				return exception.Into(err)
+			}
+			deleted = append(deleted, comment)
+			return nil
+		}
+
+		// remove comment then its deleted ancestors left without replies
+		for {
+			if err := r.Article().DeleteComment(ctx, comment); err != nil {
+				return exception.Into(err)
+			}
+			comment.Tombstone()
+			deleted = append(deleted, comment)
+			if comment.ParentID == "" {
+				return nil
+			}
+			parents, err := r.Article().FilterComment(ctx, port.FilterCommentPayload{
+				IDs: []domain.ID{comment.ParentID},
+			})
+			if err != nil {
+				return exception.Into(err)
+			}
+			if len(parents) == 0 || !parents[0].IsDeleted {
+				return nil
+			}
+			replies, err := r.Article().FilterComment(ctx, port.FilterCommentPayload{
+				ParentIDs: []domain.ID{parents[0].ID},
+				Limit:     1,
+			})
+			if err != nil {
+				return exception.Into(err)
+			}
+			if len(replies) > 0 {
+				return nil
+			}
+			comment = parents[0]
+		}
+	})
+	if err != nil {
+		return exception.Into(err)
+	}
+
+	// viewer keep tombstone when comment still has replies, otherwise remove it
+	for _, comment := range deleted {
+		s.property.publish(domain.Event{
+			Type:      domain.EventTypeCommentDeleted,
+			ArticleID: article.ID,
+			Data:      comment,
+		})
+	}
 
 	return nil
 }
 
+func (s *articleService) ListMentions(ctx context.Context, arg port.ListMentionParams) ([]domain.Mention, error) {
+	if arg.AuthArg.Payload == nil {
+		return []domain.Mention{}, exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+
+	mentions, err := s.property.repo.Article().FilterMention(ctx, port.FilterMentionPayload{
+		UserIDs: []domain.ID{arg.AuthArg.Payload.UserID},
+		Limit:   arg.Limit,
+		Offset:  arg.Offset,
+	})
+	if err != nil {
+		return []domain.Mention{}, exception.Into(err)
+	}
+	if len(mentions) == 0 {
+		return mentions, nil
+	}
+
+	// Get mention authors and articles
+	authorIDs := []domain.ID{}
+	articleIDs := []domain.ID{}
+	for _, mention := range mentions {
+		authorIDs = append(authorIDs, mention.AuthorID)
+		articleIDs = append(articleIDs, mention.ArticleID)
+	}
+	authors, err := s.property.repo.User().FilterUser(ctx, port.FilterUserPayload{IDs: authorIDs})
+	if err != nil {
+		return []domain.Mention{}, exception.Into(err)
+	}
+	authorMap := map[domain.ID]domain.User{}
+	for _, author := range authors {
+		authorMap[author.ID] = author
+	}
+	articles, err := s.property.repo.Article().FilterArticle(ctx, port.FilterArticlePayload{IDs: articleIDs})
+	if err != nil {
+		return []domain.Mention{}, exception.Into(err)
+	}
+	articleMap := map[domain.ID]domain.Article{}
+	for _, article := range articles {
+		articleMap[article.ID] = article
+	}
+
+	for i, mention := range mentions {
+		mentions[i].Author = authorMap[mention.AuthorID]
+		mentions[i].Article = articleMap[mention.ArticleID]
+	}
+
+	return mentions, nil
+}
+
+// syncMentions store users mentioned in text of source (article or comment)
+// and notify newly mentioned users, mentions removed from text are deleted.
+// Notifications are returned to be published after commit.
+// Unknown usernames and self mention are ignored
+func (s *articleService) syncMentions(ctx context.Context, r port.Repository, source domain.Mention, text string) ([]domain.Notification, error) {
+	users := []domain.User{}
+	if usernames := domain.ParseMentions(text); len(usernames) > 0 {
+		var err error
+		users, err = r.User().FilterUser(ctx, port.FilterUserPayload{Usernames: usernames})
+		if err != nil {
+			return []domain.Notification{}, exception.Into(err)
+		}
+	}
+	mentioned := map[domain.ID]bool{}
+	for _, user := range users {
+		if user.ID != source.AuthorID {
+			mentioned[user.ID] = true
+		}
+	}
+
+	existing, err := r.Article().FilterMention(ctx, port.FilterMentionPayload{
+		ArticleIDs: []domain.ID{source.ArticleID},
+	})
+	if err != nil {
+		return []domain.Notification{}, exception.Into(err)
+	}
+	staleIDs := []domain.ID{}
+	for _, mention := range existing {
+		if mention.CommentID != source.CommentID {
+			continue
+		}
+		if mentioned[mention.UserID] {
+			// already mentioned, do not notify again
+			delete(mentioned, mention.UserID)
+			continue
+		}
+		staleIDs = append(staleIDs, mention.ID)
+	}
+	if err := r.Article().DeleteMentions(ctx, staleIDs); err != nil {
+		return []domain.Notification{}, exception.Into(err)
+	}
+
+	mentions := []domain.Mention{}
+	notifications := []domain.Notification{}
+	for _, user := range users {
+		if !mentioned[user.ID] {
+			continue
+		}
+		source.UserID = user.ID
+		mentions = append(mentions, domain.NewMention(source))
+		notifications = append(notifications, domain.Notification{
+			UserID:    user.ID,
+			ActorID:   source.AuthorID,
+			Type:      domain.NotificationTypeMention,
+			ArticleID: source.ArticleID,
+			CommentID: source.CommentID,
+		})
+	}
+	if _, err := r.Article().AddMentions(ctx, mentions); err != nil {
+		return []domain.Notification{}, exception.Into(err)
+	}
+	return s.property.notify(ctx, r, notifications...)
+}
+
 type GetCommentInfo struct {
 	authArg  port.AuthParams
 	comments []domain.Comment
@@ -625,3 +1245,65 @@ func (s *articleService) ListTags(ctx context.Context) ([]string, error) {
 	}
 	return tagNames, nil
 }
+
+// intersectIDs keep ids exist in both, nil current means no filter applied yet
+func intersectIDs(current, ids []domain.ID) []domain.ID {
+	if current == nil {
+		return ids
+	}
+	idMap := map[domain.ID]bool{}
+	for _, id := range ids {
+		idMap[id] = true
+	}
+	result := []domain.ID{}
+	for _, id := range current {
+		if idMap[id] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func uniqueStrings(values []string) []string {
+	exists := map[string]bool{}
+	result := []string{}
+	for _, value := range values {
+		if exists[value] {
+			continue
+		}
+		exists[value] = true
+		result = append(result, value)
+	}
+	return result
+}
+
+func isCursorArticleSort(sort string) bool {
+	return sort == "" || sort == port.ArticleSortNewest || sort == port.ArticleSortOldest
+}
+
+func parseArticleCursor(value, sort string) (domain.Cursor, error) {
+	cursor, err := domain.ParseCursor(value)
+	if err != nil {
+		return domain.Cursor{}, exception.Validation().AddError("cursor", err.Error())
+	}
+	if !cursor.IsZero() && !isCursorArticleSort(sort) {
+		return domain.Cursor{}, exception.Validation().AddError("cursor", "only supported for newest and oldest sort")
+	}
+	return cursor, nil
+}
+
+// pageFetchLimit fetch one more item than limit to detect next page
+func pageFetchLimit(limit int) int {
+	if limit <= 0 {
+		return limit
+	}
+	return limit + 1
+}
+
+// pageOffset ignore offset when cursor provided
+func pageOffset(offset int, cursor domain.Cursor) int {
+	if !cursor.IsZero() {
+		return 0
+	}
+	return offset
+}
@@ -2,9 +2,11 @@ package service_test
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/labasubagia/realworld-backend/internal/core/domain"
 	"github.com/labasubagia/realworld-backend/internal/core/port"
@@ -162,6 +164,63 @@ func TestUpdateArticle(t *testing.T) {
 		require.True(t, ok)
 		require.Equal(t, exception.TypeNotFound, fail.Type)
 	})
+
+	t.Run("Version", func(t *testing.T) {
+		article := createRandomArticle(t, author, authorAuth)
+		require.Equal(t, 1, article.Version)
+
+		result, err := testService.Article().Update(ctx, port.UpdateArticleParams{
+			AuthArg: authorAuth,
+			Slug:    article.Slug,
+			Article: domain.Article{Body: util.RandomString(10), Version: article.Version},
+		})
+		require.Nil(t, err)
+		require.Equal(t, article.Version+1, result.Version)
+
+		// other editor still hold old version
+		_, err = testService.Article().Update(ctx, port.UpdateArticleParams{
+			AuthArg: authorAuth,
+			Slug:    article.Slug,
+			Article: domain.Article{Body: util.RandomString(10), Version: article.Version},
+		})
+		require.NotNil(t, err)
+		fail, ok := err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypeConflict, fail.Type)
+	})
+
+	t.Run("Unmodified since read", func(t *testing.T) {
+		created := createRandomArticle(t, author, authorAuth)
+		article, err := testService.Article().Get(ctx, port.GetArticleParams{Slug: created.Slug})
+		require.Nil(t, err)
+
+		// first editor update article read before
+		first, err := testService.Article().Update(ctx, port.UpdateArticleParams{
+			AuthArg:         authorAuth,
+			Slug:            article.Slug,
+			Article:         domain.Article{Body: util.RandomString(10)},
+			UnmodifiedSince: article.UpdatedAt,
+		})
+		require.Nil(t, err)
+		require.True(t, first.UpdatedAt.After(article.UpdatedAt))
+
+		// second editor read same version, fail
+		result, err := testService.Article().Update(ctx, port.UpdateArticleParams{
+			AuthArg:         authorAuth,
+			Slug:            article.Slug,
+			Article:         domain.Article{Body: util.RandomString(10)},
+			UnmodifiedSince: article.UpdatedAt,
+		})
+		require.NotNil(t, err)
+		require.Empty(t, result)
+		fail, ok := err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypePreconditionFailed, fail.Type)
+
+		current, err := testService.Article().Get(ctx, port.GetArticleParams{Slug: article.Slug})
+		require.Nil(t, err)
+		require.Equal(t, first.Body, current.Body)
+	})
 }
 
 func TestDeleteArticle(t *testing.T) {
@@ -246,16 +305,18 @@ func TestFeedArticle(t *testing.T) {
 		require.Nil(t, err)
 		require.NotEmpty(t, followResult)
 
-		result, err := testService.Article().Feed(ctx, port.ListArticleParams{AuthArg: reader1Auth})
+		result, err := testService.Article().Feed(ctx, port.ListArticleParams{AuthArg: reader1Auth, Limit: 2})
 		require.Nil(t, err)
-		require.NotEmpty(t, result)
-		require.Len(t, result, N)
+		require.NotEmpty(t, result.Articles)
+		require.Len(t, result.Articles, 2)
+		require.Equal(t, N, result.Count)
 	})
 
 	t.Run("Feed empty", func(t *testing.T) {
 		result, err := testService.Article().Feed(ctx, port.ListArticleParams{AuthArg: reader2Auth})
 		require.Nil(t, err)
-		require.Len(t, result, 0)
+		require.Len(t, result.Articles, 0)
+		require.Equal(t, 0, result.Count)
 	})
 }
 
@@ -295,12 +356,12 @@ func TestListArticleOK(t *testing.T) {
 			Offset: offset,
 		})
 		require.Nil(t, err)
-		require.Len(t, result, limit)
+		require.Len(t, result.Articles, limit)
 
 		expectedArticleIDs, articleIDs := []domain.ID{}, []domain.ID{}
 		for i := 0; i < limit; i++ {
 			expectedArticleIDs = append(expectedArticleIDs, createdArticles[offset : offset+limit][i].ID)
-			articleIDs = append(articleIDs, result[i].ID)
+			articleIDs = append(articleIDs, result.Articles[i].ID)
 		}
 		require.Equal(t, expectedArticleIDs, articleIDs)
 	})
@@ -310,7 +371,19 @@ func TestListArticleOK(t *testing.T) {
 			AuthorNames: []string{"nonexistent_author"},
 		})
 		require.Nil(t, err)
-		require.Empty(t, result)
+		require.Empty(t, result.Articles)
+	})
+
+	t.Run("Paginate count", func(t *testing.T) {
+		limit := 3
+		result, err := testService.Article().List(ctx, port.ListArticleParams{
+			AuthorNames: []string{author.Username},
+			Limit:       limit,
+			Offset:      N - 1,
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Articles, 1)
+		require.Equal(t, N, result.Count)
 	})
 
 	t.Run("Filter by author", func(t *testing.T) {
@@ -318,8 +391,9 @@ func TestListArticleOK(t *testing.T) {
 			AuthorNames: []string{author.Username},
 		})
 		require.Nil(t, err)
-		require.Len(t, result, N)
-		for _, article := range result {
+		require.Len(t, result.Articles, N)
+		require.Equal(t, N, result.Count)
+		for _, article := range result.Articles {
 			require.Equal(t, author.ID, article.AuthorID)
 			require.NotEqual(t, otherAuthor.ID, article.AuthorID)
 		}
@@ -330,14 +404,14 @@ func TestListArticleOK(t *testing.T) {
 			Tags: []string{"nonexistent_tag"},
 		})
 		require.Nil(t, err)
-		require.Empty(t, result)
+		require.Empty(t, result.Articles)
 	})
 
 	t.Run("Filter by tags", func(t *testing.T) {
 		result, err := testService.Article().List(ctx, port.ListArticleParams{Tags: tags})
 		require.Nil(t, err)
-		require.Len(t, result, N)
-		for _, article := range result {
+		require.Len(t, result.Articles, N)
+		for _, article := range result.Articles {
 			sort.Strings(article.TagNames)
 			require.Equal(t, tags, article.TagNames)
 		}
@@ -348,7 +422,7 @@ func TestListArticleOK(t *testing.T) {
 			FavoritedNames: []string{"nonexistent_fav_name"},
 		})
 		require.Nil(t, err)
-		require.Empty(t, result)
+		require.Empty(t, result.Articles)
 	})
 
 	t.Run("Filter by favorites", func(t *testing.T) {
@@ -369,14 +443,299 @@ func TestListArticleOK(t *testing.T) {
 			FavoritedNames: []string{reader.Username},
 		})
 		require.Nil(t, err)
-		require.Len(t, result, favN)
-		for _, article := range result {
+		require.Len(t, result.Articles, favN)
+		for _, article := range result.Articles {
 			require.Greater(t, article.FavoriteCount, 0)
 			require.True(t, article.IsFavorite)
 		}
 	})
 }
 
+func TestListArticleCombinedFilter(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	reader, readerAuth, _ := createRandomUser(t)
+	ctx := context.Background()
+
+	tag1, tag2 := util.RandomString(8), util.RandomString(9)
+
+	// article tagged with both tags
+	bothArg := createArticleArg(author, authorAuth)
+	bothArg.Tags = []string{tag1, tag2}
+	both := createArticle(t, bothArg)
+
+	// article tagged with tag1 only
+	onlyArg := createArticleArg(author, authorAuth)
+	onlyArg.Tags = []string{tag1}
+	only := createArticle(t, onlyArg)
+
+	t.Run("Multiple tags any", func(t *testing.T) {
+		result, err := testService.Article().List(ctx, port.ListArticleParams{
+			Tags:    []string{tag1, tag2},
+			TagMode: port.TagModeAny,
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Articles, 2)
+	})
+
+	t.Run("Multiple tags all", func(t *testing.T) {
+		result, err := testService.Article().List(ctx, port.ListArticleParams{
+			Tags:    []string{tag1, tag2},
+			TagMode: port.TagModeAll,
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Articles, 1)
+		require.Equal(t, 1, result.Count)
+		require.Equal(t, both.ID, result.Articles[0].ID)
+	})
+
+	t.Run("Multiple tags all with nonexistent tag", func(t *testing.T) {
+		result, err := testService.Article().List(ctx, port.ListArticleParams{
+			Tags:    []string{tag1, "nonexistent_tag"},
+			TagMode: port.TagModeAll,
+		})
+		require.Nil(t, err)
+		require.Empty(t, result.Articles)
+	})
+
+	t.Run("Invalid tag mode", func(t *testing.T) {
+		result, err := testService.Article().List(ctx, port.ListArticleParams{
+			Tags:    []string{tag1},
+			TagMode: "invalid",
+		})
+		require.NotNil(t, err)
+		require.Empty(t, result.Articles)
+		fail, ok := err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypeValidation, fail.Type)
+	})
+
+	t.Run("Tag and favorited intersect", func(t *testing.T) {
+		_, err := testService.Article().AddFavorite(ctx, port.AddFavoriteParams{
+			AuthArg: readerAuth,
+			Slug:    only.Slug,
+			UserID:  reader.ID,
+		})
+		require.Nil(t, err)
+
+		result, err := testService.Article().List(ctx, port.ListArticleParams{
+			Tags:           []string{tag2},
+			FavoritedNames: []string{reader.Username},
+		})
+		require.Nil(t, err)
+		require.Empty(t, result.Articles)
+
+		result, err = testService.Article().List(ctx, port.ListArticleParams{
+			Tags:           []string{tag1},
+			FavoritedNames: []string{reader.Username},
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Articles, 1)
+		require.Equal(t, only.ID, result.Articles[0].ID)
+	})
+
+	t.Run("Created range", func(t *testing.T) {
+		result, err := testService.Article().List(ctx, port.ListArticleParams{
+			AuthorNames:   []string{author.Username},
+			CreatedAfter:  both.CreatedAt.Add(-time.Minute),
+			CreatedBefore: only.CreatedAt.Add(time.Minute),
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Articles, 2)
+
+		result, err = testService.Article().List(ctx, port.ListArticleParams{
+			AuthorNames:  []string{author.Username},
+			CreatedAfter: only.CreatedAt.Add(time.Minute),
+		})
+		require.Nil(t, err)
+		require.Empty(t, result.Articles)
+	})
+}
+
+func TestListArticleSort(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	ctx := context.Background()
+
+	// articles in created order
+	N := 3
+	articles := make([]domain.Article, N)
+	for i := 0; i < N; i++ {
+		articles[i] = createRandomArticle(t, author, authorAuth)
+	}
+
+	// article i get i favorites and i comments
+	for i, article := range articles {
+		for j := 0; j < i; j++ {
+			user, userAuth, _ := createRandomUser(t)
+			_, err := testService.Article().AddFavorite(ctx, port.AddFavoriteParams{
+				AuthArg: userAuth,
+				Slug:    article.Slug,
+				UserID:  user.ID,
+			})
+			require.Nil(t, err)
+			_, err = testService.Article().AddComment(ctx, port.AddCommentParams{
+				AuthArg: userAuth,
+				Slug:    article.Slug,
+				Comment: domain.Comment{Body: util.RandomString(10)},
+			})
+			require.Nil(t, err)
+		}
+	}
+
+	// first article updated last
+	_, err := testService.Article().Update(ctx, port.UpdateArticleParams{
+		AuthArg: authorAuth,
+		Slug:    articles[0].Slug,
+		Article: domain.Article{Body: util.RandomString(20)},
+	})
+	require.Nil(t, err)
+
+	listIDs := func(t *testing.T, sort string) []domain.ID {
+		result, err := testService.Article().List(ctx, port.ListArticleParams{
+			AuthorNames: []string{author.Username},
+			Sort:        sort,
+		})
+		require.Nil(t, err)
+		ids := []domain.ID{}
+		for _, article := range result.Articles {
+			ids = append(ids, article.ID)
+		}
+		return ids
+	}
+
+	newest := []domain.ID{articles[2].ID, articles[1].ID, articles[0].ID}
+	oldest := []domain.ID{articles[0].ID, articles[1].ID, articles[2].ID}
+
+	require.Equal(t, newest, listIDs(t, ""))
+	require.Equal(t, newest, listIDs(t, port.ArticleSortNewest))
+	require.Equal(t, oldest, listIDs(t, port.ArticleSortOldest))
+	require.Equal(t, newest, listIDs(t, port.ArticleSortMostFavorited))
+	require.Equal(t, newest, listIDs(t, port.ArticleSortMostCommented))
+	require.Equal(t, articles[0].ID, listIDs(t, port.ArticleSortRecentlyUpdated)[0])
+
+	t.Run("Top this week", func(t *testing.T) {
+		result, err := testService.Article().List(ctx, port.ListArticleParams{
+			AuthorNames:  []string{author.Username},
+			CreatedAfter: time.Now().AddDate(0, 0, -7),
+			Sort:         port.ArticleSortMostFavorited,
+			Limit:        1,
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Articles, 1)
+		require.Equal(t, articles[N-1].ID, result.Articles[0].ID)
+		require.Equal(t, N-1, result.Articles[0].FavoriteCount)
+	})
+
+	t.Run("Invalid sort", func(t *testing.T) {
+		result, err := testService.Article().List(ctx, port.ListArticleParams{Sort: "invalid"})
+		require.NotNil(t, err)
+		require.Empty(t, result.Articles)
+		fail, ok := err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypeValidation, fail.Type)
+	})
+}
+
+func TestListArticleCursor(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	_, readerAuth, _ := createRandomUser(t)
+	ctx := context.Background()
+
+	N := 5
+	created := make([]domain.ID, N)
+	for i := 0; i < N; i++ {
+		created[i] = createRandomArticle(t, author, authorAuth).ID
+	}
+	newest := make([]domain.ID, N)
+	for i, id := range created {
+		newest[N-1-i] = id
+	}
+
+	paginate := func(t *testing.T, list func(port.ListArticleParams) (port.ListArticleResult, error), sort string) []domain.ID {
+		ids := []domain.ID{}
+		cursor := ""
+		for page := 0; page <= N; page++ {
+			result, err := list(port.ListArticleParams{
+				AuthArg:     readerAuth,
+				AuthorNames: []string{author.Username},
+				Sort:        sort,
+				Cursor:      cursor,
+				Limit:       2,
+			})
+			require.Nil(t, err)
+			require.LessOrEqual(t, len(result.Articles), 2)
+			for _, article := range result.Articles {
+				ids = append(ids, article.ID)
+			}
+			if result.NextCursor == "" {
+				break
+			}
+			cursor = result.NextCursor
+		}
+		return ids
+	}
+	list := func(arg port.ListArticleParams) (port.ListArticleResult, error) {
+		return testService.Article().List(ctx, arg)
+	}
+
+	t.Run("Newest", func(t *testing.T) {
+		require.Equal(t, newest, paginate(t, list, port.ArticleSortNewest))
+	})
+
+	t.Run("Oldest", func(t *testing.T) {
+		require.Equal(t, created, paginate(t, list, port.ArticleSortOldest))
+	})
+
+	t.Run("Feed", func(t *testing.T) {
+		_, err := testService.User().Follow(ctx, port.ProfileParams{AuthArg: readerAuth, Username: author.Username})
+		require.Nil(t, err)
+		feed := func(arg port.ListArticleParams) (port.ListArticleResult, error) {
+			return testService.Article().Feed(ctx, arg)
+		}
+		require.Equal(t, newest, paginate(t, feed, ""))
+	})
+
+	t.Run("Cursor take precedence over offset", func(t *testing.T) {
+		first, err := testService.Article().List(ctx, port.ListArticleParams{
+			AuthorNames: []string{author.Username},
+			Limit:       1,
+		})
+		require.Nil(t, err)
+		require.NotEmpty(t, first.NextCursor)
+
+		result, err := testService.Article().List(ctx, port.ListArticleParams{
+			AuthorNames: []string{author.Username},
+			Cursor:      first.NextCursor,
+			Offset:      3,
+			Limit:       1,
+		})
+		require.Nil(t, err)
+		require.Len(t, result.Articles, 1)
+		require.Equal(t, newest[1], result.Articles[0].ID)
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		result, err := testService.Article().List(ctx, port.ListArticleParams{Cursor: "invalid"})
+		require.NotNil(t, err)
+		require.Empty(t, result.Articles)
+		fail, ok := err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypeValidation, fail.Type)
+	})
+
+	t.Run("Cursor with unsupported sort", func(t *testing.T) {
+		cursor := domain.NewCursor(time.Now(), domain.NewID()).String()
+		result, err := testService.Article().List(ctx, port.ListArticleParams{
+			Cursor: cursor,
+			Sort:   port.ArticleSortMostFavorited,
+		})
+		require.NotNil(t, err)
+		require.Empty(t, result.Articles)
+		fail, ok := err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypeValidation, fail.Type)
+	})
+}
+
 func TestGetArticle(t *testing.T) {
 	author, authorAuth, _ := createRandomUser(t)
 	_, readerAuth, _ := createRandomUser(t)
@@ -433,7 +792,7 @@ func TestCreateComment(t *testing.T) {
 		Slug:    article.Slug,
 	})
 	require.Nil(t, err)
-	require.Len(t, comments, 2)
+	require.Len(t, comments.Comments, 2)
 }
 
 func TestDeleteComment(t *testing.T) {
@@ -459,7 +818,7 @@ func TestDeleteComment(t *testing.T) {
 		Slug:    arg.Slug,
 	})
 	require.Nil(t, err)
-	require.Len(t, comments, 1)
+	require.Len(t, comments.Comments, 1)
 
 	// user delete his comment
 	err = testService.Article().DeleteComment(ctx, port.DeleteCommentParams{
@@ -475,7 +834,51 @@ func TestDeleteComment(t *testing.T) {
 		Slug:    arg.Slug,
 	})
 	require.Nil(t, err)
-	require.Len(t, comments, 0)
+	require.Len(t, comments.Comments, 0)
+}
+
+func TestListCommentCursor(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	_, userAuth, _ := createRandomUser(t)
+	article := createRandomArticle(t, author, authorAuth)
+	ctx := context.Background()
+
+	N := 5
+	created := make([]domain.ID, N)
+	for i := 0; i < N; i++ {
+		comment, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+			AuthArg: userAuth,
+			Slug:    article.Slug,
+			Comment: domain.Comment{Body: util.RandomString(10)},
+		})
+		require.Nil(t, err)
+		created[i] = comment.ID
+	}
+
+	ids := []domain.ID{}
+	cursor := ""
+	for page := 0; page <= N; page++ {
+		result, err := testService.Article().ListComments(ctx, port.ListCommentParams{
+			Slug:   article.Slug,
+			Cursor: cursor,
+			Limit:  2,
+		})
+		require.Nil(t, err)
+		for _, comment := range result.Comments {
+			ids = append(ids, comment.ID)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		cursor = result.NextCursor
+	}
+	require.Equal(t, created, ids)
+
+	// without limit return all
+	result, err := testService.Article().ListComments(ctx, port.ListCommentParams{Slug: article.Slug})
+	require.Nil(t, err)
+	require.Len(t, result.Comments, N)
+	require.Empty(t, result.NextCursor)
 }
 
 func TestGetTags(t *testing.T) {
@@ -538,3 +941,425 @@ func createArticleArg(author domain.User, authorAuth port.AuthParams) port.Creat
 		Tags:    []string{util.RandomString(6), util.RandomString(7)},
 	}
 }
+
+func TestCommentReply(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	_, userAuth, _ := createRandomUser(t)
+	article := createRandomArticle(t, author, authorAuth)
+	ctx := context.Background()
+
+	// build a chain of replies up to max depth
+	chain := []domain.Comment{}
+	parentID := domain.ID("")
+	for depth := 0; depth <= domain.CommentMaxDepth; depth++ {
+		comment, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+			AuthArg: userAuth,
+			Slug:    article.Slug,
+			Comment: domain.Comment{ParentID: parentID, Body: util.RandomString(10)},
+		})
+		require.Nil(t, err)
+		require.Equal(t, parentID, comment.ParentID)
+		require.Equal(t, depth, comment.Depth)
+		chain = append(chain, comment)
+		parentID = comment.ID
+	}
+
+	t.Run("Too deep", func(t *testing.T) {
+		_, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+			AuthArg: userAuth,
+			Slug:    article.Slug,
+			Comment: domain.Comment{ParentID: parentID, Body: util.RandomString(10)},
+		})
+		require.NotNil(t, err)
+		fail, ok := err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypeValidation, fail.Type)
+	})
+
+	t.Run("Parent other article", func(t *testing.T) {
+		other := createRandomArticle(t, author, authorAuth)
+		_, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+			AuthArg: userAuth,
+			Slug:    other.Slug,
+			Comment: domain.Comment{ParentID: chain[0].ID, Body: util.RandomString(10)},
+		})
+		require.NotNil(t, err)
+		fail, ok := err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypeValidation, fail.Type)
+	})
+
+	t.Run("Flat", func(t *testing.T) {
+		result, err := testService.Article().ListComments(ctx, port.ListCommentParams{Slug: article.Slug})
+		require.Nil(t, err)
+		require.Len(t, result.Comments, len(chain))
+		for i, comment := range result.Comments {
+			require.Equal(t, chain[i].ID, comment.ID)
+			require.Equal(t, chain[i].ParentID, comment.ParentID)
+			require.Empty(t, comment.Replies)
+		}
+	})
+
+	t.Run("Tree", func(t *testing.T) {
+		result, err := testService.Article().ListComments(ctx, port.ListCommentParams{Slug: article.Slug, Tree: true})
+		require.Nil(t, err)
+		require.Len(t, result.Comments, 1)
+
+		node := result.Comments[0]
+		for i, comment := range chain {
+			require.Equal(t, comment.ID, node.ID)
+			require.NotEmpty(t, node.Author.Username)
+			if i == len(chain)-1 {
+				require.Empty(t, node.Replies)
+				break
+			}
+			require.Len(t, node.Replies, 1)
+			node = node.Replies[0]
+		}
+	})
+}
+
+func TestListCommentTreeCursor(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	_, userAuth, _ := createRandomUser(t)
+	article := createRandomArticle(t, author, authorAuth)
+	ctx := context.Background()
+
+	N := 3
+	roots := make([]domain.ID, N)
+	for i := 0; i < N; i++ {
+		root, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+			AuthArg: userAuth,
+			Slug:    article.Slug,
+			Comment: domain.Comment{Body: util.RandomString(10)},
+		})
+		require.Nil(t, err)
+		roots[i] = root.ID
+
+		_, err = testService.Article().AddComment(ctx, port.AddCommentParams{
+			AuthArg: authorAuth,
+			Slug:    article.Slug,
+			Comment: domain.Comment{ParentID: root.ID, Body: util.RandomString(10)},
+		})
+		require.Nil(t, err)
+	}
+
+	// page only count root comments
+	ids := []domain.ID{}
+	cursor := ""
+	for page := 0; page <= N; page++ {
+		result, err := testService.Article().ListComments(ctx, port.ListCommentParams{
+			Slug:   article.Slug,
+			Tree:   true,
+			Cursor: cursor,
+			Limit:  2,
+		})
+		require.Nil(t, err)
+		for _, comment := range result.Comments {
+			require.Len(t, comment.Replies, 1)
+			ids = append(ids, comment.ID)
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		cursor = result.NextCursor
+	}
+	require.Equal(t, roots, ids)
+}
+
+func TestDeleteCommentWithReplies(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	_, userAuth, _ := createRandomUser(t)
+	article := createRandomArticle(t, author, authorAuth)
+	ctx := context.Background()
+
+	parent, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: userAuth,
+		Slug:    article.Slug,
+		Comment: domain.Comment{Body: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+	reply, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: authorAuth,
+		Slug:    article.Slug,
+		Comment: domain.Comment{ParentID: parent.ID, Body: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+
+	// parent become tombstone, reply keep
+	err = testService.Article().DeleteComment(ctx, port.DeleteCommentParams{
+		AuthArg:   userAuth,
+		Slug:      article.Slug,
+		CommentID: parent.ID,
+	})
+	require.Nil(t, err)
+
+	result, err := testService.Article().ListComments(ctx, port.ListCommentParams{Slug: article.Slug, Tree: true})
+	require.Nil(t, err)
+	require.Len(t, result.Comments, 1)
+	require.Equal(t, parent.ID, result.Comments[0].ID)
+	require.True(t, result.Comments[0].IsDeleted)
+	require.Equal(t, domain.CommentDeletedBody, result.Comments[0].Body)
+	require.Len(t, result.Comments[0].Replies, 1)
+	require.Equal(t, reply.ID, result.Comments[0].Replies[0].ID)
+
+	// cannot reply to tombstone
+	_, err = testService.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: authorAuth,
+		Slug:    article.Slug,
+		Comment: domain.Comment{ParentID: parent.ID, Body: util.RandomString(10)},
+	})
+	require.NotNil(t, err)
+
+	// other user cannot delete comment
+	err = testService.Article().DeleteComment(ctx, port.DeleteCommentParams{
+		AuthArg:   userAuth,
+		Slug:      article.Slug,
+		CommentID: reply.ID,
+	})
+	require.NotNil(t, err)
+
+	// deleting last reply also remove tombstone
+	err = testService.Article().DeleteComment(ctx, port.DeleteCommentParams{
+		AuthArg:   authorAuth,
+		Slug:      article.Slug,
+		CommentID: reply.ID,
+	})
+	require.Nil(t, err)
+
+	result, err = testService.Article().ListComments(ctx, port.ListCommentParams{Slug: article.Slug})
+	require.Nil(t, err)
+	require.Len(t, result.Comments, 0)
+}
+
+func TestUpdateComment(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	_, userAuth, _ := createRandomUser(t)
+	article := createRandomArticle(t, author, authorAuth)
+	ctx := context.Background()
+
+	comment, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: userAuth,
+		Slug:    article.Slug,
+		Comment: domain.Comment{Body: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+	require.False(t, comment.IsEdited)
+
+	t.Run("Other user", func(t *testing.T) {
+		_, err := testService.Article().UpdateComment(ctx, port.UpdateCommentParams{
+			AuthArg:   authorAuth,
+			Slug:      article.Slug,
+			CommentID: comment.ID,
+			Comment:   domain.Comment{Body: util.RandomString(10)},
+		})
+		require.NotNil(t, err)
+		fail, ok := err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypePermissionDenied, fail.Type)
+	})
+
+	t.Run("Keep revisions", func(t *testing.T) {
+		bodies := []string{comment.Body}
+		for i := 0; i < 2; i++ {
+			body := util.RandomString(12)
+			updated, err := testService.Article().UpdateComment(ctx, port.UpdateCommentParams{
+				AuthArg:   userAuth,
+				Slug:      article.Slug,
+				CommentID: comment.ID,
+				Comment:   domain.Comment{Body: body},
+			})
+			require.Nil(t, err)
+			require.Equal(t, comment.ID, updated.ID)
+			require.Equal(t, body, updated.Body)
+			require.True(t, updated.IsEdited)
+			require.True(t, updated.UpdatedAt.After(comment.CreatedAt))
+			require.NotEmpty(t, updated.Author.Username)
+			bodies = append(bodies, body)
+		}
+
+		revisions, err := testRepo.Article().FilterCommentRevision(ctx, port.FilterCommentRevisionPayload{
+			CommentIDs: []domain.ID{comment.ID},
+		})
+		require.Nil(t, err)
+		require.Len(t, revisions, 2)
+		for i, revision := range revisions {
+			require.Equal(t, bodies[i], revision.Body)
+		}
+
+		result, err := testService.Article().ListComments(ctx, port.ListCommentParams{Slug: article.Slug})
+		require.Nil(t, err)
+		require.Len(t, result.Comments, 1)
+		require.True(t, result.Comments[0].IsEdited)
+		require.Equal(t, bodies[len(bodies)-1], result.Comments[0].Body)
+	})
+
+	t.Run("List revisions", func(t *testing.T) {
+		revisions, err := testService.Article().ListCommentRevisions(ctx, port.ListCommentRevisionParams{
+			AuthArg:   userAuth,
+			Slug:      article.Slug,
+			CommentID: comment.ID,
+		})
+		require.Nil(t, err)
+		require.Len(t, revisions, 2)
+		require.Equal(t, comment.Body, revisions[0].Body)
+		for _, revision := range revisions {
+			require.Equal(t, comment.ID, revision.CommentID)
+		}
+
+		// other user, even article author, cannot see previous body
+		_, err = testService.Article().ListCommentRevisions(ctx, port.ListCommentRevisionParams{
+			AuthArg:   authorAuth,
+			Slug:      article.Slug,
+			CommentID: comment.ID,
+		})
+		require.NotNil(t, err)
+		fail, ok := err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypePermissionDenied, fail.Type)
+
+		_, err = testService.Article().ListCommentRevisions(ctx, port.ListCommentRevisionParams{
+			Slug:      article.Slug,
+			CommentID: comment.ID,
+		})
+		require.NotNil(t, err)
+	})
+}
+
+func TestListCommentSort(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	_, userAuth, _ := createRandomUser(t)
+	article := createRandomArticle(t, author, authorAuth)
+	ctx := context.Background()
+
+	N := 5
+	created := make([]domain.ID, N)
+	for i := 0; i < N; i++ {
+		comment, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+			AuthArg: userAuth,
+			Slug:    article.Slug,
+			Comment: domain.Comment{Body: util.RandomString(10)},
+		})
+		require.Nil(t, err)
+		created[i] = comment.ID
+	}
+	reversed := make([]domain.ID, N)
+	for i, id := range created {
+		reversed[N-1-i] = id
+	}
+
+	testCases := []struct {
+		sort     string
+		expected []domain.ID
+	}{
+		{sort: "", expected: created},
+		{sort: port.CommentSortOldest, expected: created},
+		{sort: port.CommentSortNewest, expected: reversed},
+	}
+	for _, tc := range testCases {
+		t.Run("Sort "+tc.sort, func(t *testing.T) {
+			ids := []domain.ID{}
+			cursor := ""
+			for page := 0; page <= N; page++ {
+				result, err := testService.Article().ListComments(ctx, port.ListCommentParams{
+					Slug:   article.Slug,
+					Sort:   tc.sort,
+					Cursor: cursor,
+					Limit:  2,
+				})
+				require.Nil(t, err)
+				require.Equal(t, N, result.Count)
+				for _, comment := range result.Comments {
+					ids = append(ids, comment.ID)
+				}
+				if result.NextCursor == "" {
+					break
+				}
+				cursor = result.NextCursor
+			}
+			require.Equal(t, tc.expected, ids)
+		})
+	}
+
+	t.Run("Invalid sort", func(t *testing.T) {
+		_, err := testService.Article().ListComments(ctx, port.ListCommentParams{
+			Slug: article.Slug,
+			Sort: "random",
+		})
+		require.NotNil(t, err)
+		fail, ok := err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypeValidation, fail.Type)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		other := createRandomArticle(t, author, authorAuth)
+		result, err := testService.Article().ListComments(ctx, port.ListCommentParams{Slug: other.Slug})
+		require.Nil(t, err)
+		require.Equal(t, 0, result.Count)
+		require.Empty(t, result.Comments)
+	})
+}
+
+func TestMention(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	alice, aliceAuth, _ := createRandomUser(t)
+	bob, bobAuth, _ := createRandomUser(t)
+	ctx := context.Background()
+
+	listMentions := func(authArg port.AuthParams) []domain.Mention {
+		mentions, err := testService.Article().ListMentions(ctx, port.ListMentionParams{AuthArg: authArg, Limit: 10})
+		require.Nil(t, err)
+		return mentions
+	}
+
+	arg := createArticleArg(author, authorAuth)
+	arg.Article.Body = fmt.Sprintf("hi @%s, @%s and @unknown_%s", alice.Username, author.Username, util.RandomString(8))
+	article := createArticle(t, arg)
+
+	t.Run("Create", func(t *testing.T) {
+		mentions := listMentions(aliceAuth)
+		require.Len(t, mentions, 1)
+		require.Equal(t, article.ID, mentions[0].ArticleID)
+		require.Equal(t, article.Slug, mentions[0].Article.Slug)
+		require.Equal(t, author.Username, mentions[0].Author.Username)
+		require.Empty(t, mentions[0].CommentID)
+
+		// self mention ignored
+		require.Empty(t, listMentions(authorAuth))
+		require.Empty(t, listMentions(bobAuth))
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		_, err := testService.Article().Update(ctx, port.UpdateArticleParams{
+			AuthArg: authorAuth,
+			Slug:    article.Slug,
+			Article: domain.Article{Body: fmt.Sprintf("hi @%s", bob.Username)},
+		})
+		require.Nil(t, err)
+		require.Empty(t, listMentions(aliceAuth))
+		require.Len(t, listMentions(bobAuth), 1)
+	})
+
+	t.Run("Comment", func(t *testing.T) {
+		comment, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+			AuthArg: bobAuth,
+			Slug:    article.Slug,
+			Comment: domain.Comment{Body: fmt.Sprintf("@%s @%s look", alice.Username, alice.Username)},
+		})
+		require.Nil(t, err)
+
+		mentions := listMentions(aliceAuth)
+		require.Len(t, mentions, 1)
+		require.Equal(t, comment.ID, mentions[0].CommentID)
+		require.Equal(t, bob.Username, mentions[0].Author.Username)
+
+		// article mention of bob not affected by comment
+		require.Len(t, listMentions(bobAuth), 1)
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		_, err := testService.Article().ListMentions(ctx, port.ListMentionParams{})
+		require.NotNil(t, err)
+	})
+}
@@ -0,0 +1,98 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/logger"
+	"github.com/labasubagia/realworld-backend/internal/adapter/repository/cache"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/service"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheRepository(t *testing.T) {
+	config := testConfig
+	config.CacheSize = 100
+	log := logger.NewLogger(config)
+	repo := cache.NewRepository(config, testRepo, log)
+	svc, err := service.NewService(config, repo, log)
+	require.Nil(t, err)
+
+	author, authorAuth, _ := createRandomUser(t)
+	ctx := context.Background()
+
+	// profile
+	_, err = svc.User().Profile(ctx, port.ProfileParams{Username: author.Username})
+	require.Nil(t, err)
+	_, err = svc.User().Profile(ctx, port.ProfileParams{Username: author.Username})
+	require.Nil(t, err)
+	require.Equal(t, cache.Stats{Hits: 1, Misses: 1}, repo.Stats())
+
+	// changed username invalidate old one
+	newUsername := util.RandomUsername()
+	_, err = svc.User().Update(ctx, port.UpdateUserParams{
+		AuthArg: authorAuth,
+		User:    domain.User{ID: author.ID, Username: newUsername},
+	})
+	require.Nil(t, err)
+	_, err = svc.User().Profile(ctx, port.ProfileParams{Username: author.Username})
+	fail, ok := err.(*exception.Exception)
+	require.True(t, ok)
+	require.Equal(t, exception.TypeNotFound, fail.Type)
+	profile, err := svc.User().Profile(ctx, port.ProfileParams{Username: newUsername})
+	require.Nil(t, err)
+	require.Equal(t, author.ID, profile.ID)
+	author.Username = newUsername
+
+	// article
+	arg := createArticleArg(author, authorAuth)
+	arg.Tags = []string{util.RandomString(8)}
+	article, err := svc.Article().Create(ctx, arg)
+	require.Nil(t, err)
+
+	_, err = svc.Article().Get(ctx, port.GetArticleParams{Slug: article.Slug})
+	require.Nil(t, err)
+	stats := repo.Stats()
+	_, err = svc.Article().Get(ctx, port.GetArticleParams{Slug: article.Slug})
+	require.Nil(t, err)
+	require.Equal(t, stats.Hits+1, repo.Stats().Hits)
+
+	// update in transaction invalidate after commit
+	newBody := util.RandomString(20)
+	_, err = svc.Article().Update(ctx, port.UpdateArticleParams{
+		AuthArg: authorAuth,
+		Slug:    article.Slug,
+		Article: domain.Article{Title: article.Title, Description: article.Description, Body: newBody},
+	})
+	require.Nil(t, err)
+	result, err := svc.Article().Get(ctx, port.GetArticleParams{Slug: article.Slug})
+	require.Nil(t, err)
+	require.Equal(t, newBody, result.Body)
+
+	err = svc.Article().Delete(ctx, port.DeleteArticleParams{AuthArg: authorAuth, Slug: article.Slug})
+	require.Nil(t, err)
+	_, err = svc.Article().Get(ctx, port.GetArticleParams{Slug: article.Slug})
+	fail, ok = err.(*exception.Exception)
+	require.True(t, ok)
+	require.Equal(t, exception.TypeNotFound, fail.Type)
+
+	// new tag invalidate tag list
+	_, err = svc.Article().ListTags(ctx)
+	require.Nil(t, err)
+	stats = repo.Stats()
+	_, err = svc.Article().ListTags(ctx)
+	require.Nil(t, err)
+	require.Equal(t, stats.Hits+1, repo.Stats().Hits)
+
+	arg = createArticleArg(author, authorAuth)
+	arg.Tags = []string{util.RandomString(8)}
+	_, err = svc.Article().Create(ctx, arg)
+	require.Nil(t, err)
+	tags, err := svc.Article().ListTags(ctx)
+	require.Nil(t, err)
+	require.Contains(t, tags, arg.Tags[0])
+}
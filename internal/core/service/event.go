@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+)
+
+type eventService struct {
+	property serviceProperty
+}
+
+func NewEventService(property serviceProperty) port.EventService {
+	return &eventService{
+		property: property,
+	}
+}
+
+func (s *eventService) Subscribe(ctx context.Context, arg port.SubscribeEventParams) (port.EventSubscription, error) {
+	if arg.AuthArg.Payload == nil {
+		return nil, exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+	userID := arg.AuthArg.Payload.UserID
+
+	viewing := map[domain.ID]bool{}
+	if len(arg.ArticleSlugs) > 0 {
+		articles, err := s.property.repo.Article().FilterArticle(ctx, port.FilterArticlePayload{Slugs: arg.ArticleSlugs})
+		if err != nil {
+			return nil, exception.Into(err)
+		}
+		for _, article := range articles {
+			viewing[article.ID] = true
+		}
+	}
+
+	sub := s.property.broker.Subscribe(arg.LastEventID, func(event domain.Event) bool {
+		if event.IsComment() {
+			return viewing[event.ArticleID]
+		}
+		return event.IsFor(userID)
+	})
+	return sub, nil
+}
+
+// SubscribeComments receive comment thread changes of an article, open for anonymous reader
+func (s *eventService) SubscribeComments(ctx context.Context, arg port.SubscribeCommentParams) (port.EventSubscription, error) {
+	article, err := s.property.repo.Article().FindOneArticle(ctx, port.FilterArticlePayload{
+		Slugs: []string{arg.Slug},
+	})
+	if err != nil {
+		return nil, exception.Into(err)
+	}
+
+	sub := s.property.broker.Subscribe(arg.LastEventID, func(event domain.Event) bool {
+		return event.IsComment() && event.ArticleID == article.ID
+	})
+	return sub, nil
+}
+
+// publish send event to subscribers
+func (p serviceProperty) publish(event domain.Event) {
+	p.broker.Publish(domain.NewEvent(event))
+}
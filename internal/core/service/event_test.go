@@ -0,0 +1,157 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeEventUnauthenticated(t *testing.T) {
+	_, err := testService.Event().Subscribe(context.Background(), port.SubscribeEventParams{})
+	require.NotNil(t, err)
+	fail, ok := err.(*exception.Exception)
+	require.True(t, ok)
+	require.Equal(t, exception.TypePermissionDenied, fail.Type)
+}
+
+func TestSubscribeEvent(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	_, followerAuth, _ := createRandomUser(t)
+	_, viewerAuth, _ := createRandomUser(t)
+	ctx := context.Background()
+
+	_, err := testService.User().Follow(ctx, port.ProfileParams{AuthArg: followerAuth, Username: author.Username})
+	require.Nil(t, err)
+	article := createRandomArticle(t, author, authorAuth)
+
+	follower, err := testService.Event().Subscribe(ctx, port.SubscribeEventParams{AuthArg: followerAuth})
+	require.Nil(t, err)
+	defer follower.Close()
+	viewer, err := testService.Event().Subscribe(ctx, port.SubscribeEventParams{
+		AuthArg:      viewerAuth,
+		ArticleSlugs: []string{article.Slug},
+	})
+	require.Nil(t, err)
+	defer viewer.Close()
+
+	// follower get new article in feed
+	created := createRandomArticle(t, author, authorAuth)
+	event := receiveEvent(t, follower, domain.EventTypeArticle)
+	require.Equal(t, created.ID, event.ArticleID)
+	require.Equal(t, created.Slug, event.Data.(domain.Article).Slug)
+
+	// viewer get comment, author get notification
+	authorSub, err := testService.Event().Subscribe(ctx, port.SubscribeEventParams{AuthArg: authorAuth})
+	require.Nil(t, err)
+	defer authorSub.Close()
+	comment, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: viewerAuth,
+		Slug:    article.Slug,
+		Comment: domain.Comment{Body: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+	event = receiveEvent(t, viewer, domain.EventTypeComment)
+	require.Equal(t, comment.ID, event.Data.(domain.Comment).ID)
+	event = receiveEvent(t, authorSub, domain.EventTypeNotification)
+	notification := event.Data.(domain.Notification)
+	require.Equal(t, domain.NotificationTypeComment, notification.Type)
+	require.Equal(t, comment.ID, notification.CommentID)
+	require.Equal(t, article.Slug, notification.Article.Slug)
+
+	t.Run("Resume", func(t *testing.T) {
+		last := event.ID
+		_, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+			AuthArg: viewerAuth,
+			Slug:    article.Slug,
+			Comment: domain.Comment{Body: util.RandomString(10)},
+		})
+		require.Nil(t, err)
+
+		resumed, err := testService.Event().Subscribe(ctx, port.SubscribeEventParams{
+			AuthArg:     authorAuth,
+			LastEventID: last,
+		})
+		require.Nil(t, err)
+		defer resumed.Close()
+		event := receiveEvent(t, resumed, domain.EventTypeNotification)
+		require.NotEqual(t, last, event.ID)
+	})
+}
+
+func TestSubscribeComments(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	_, userAuth, _ := createRandomUser(t)
+	article := createRandomArticle(t, author, authorAuth)
+	other := createRandomArticle(t, author, authorAuth)
+	ctx := context.Background()
+
+	_, err := testService.Event().SubscribeComments(ctx, port.SubscribeCommentParams{Slug: util.RandomString(10)})
+	require.NotNil(t, err)
+
+	// anonymous reader
+	sub, err := testService.Event().SubscribeComments(ctx, port.SubscribeCommentParams{Slug: article.Slug})
+	require.Nil(t, err)
+	defer sub.Close()
+
+	_, err = testService.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: userAuth,
+		Slug:    other.Slug,
+		Comment: domain.Comment{Body: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+	parent, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: userAuth,
+		Slug:    article.Slug,
+		Comment: domain.Comment{Body: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+	reply, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: authorAuth,
+		Slug:    article.Slug,
+		Comment: domain.Comment{ParentID: parent.ID, Body: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+
+	// other article comment not received
+	event := receiveEvent(t, sub, domain.EventTypeComment)
+	require.Equal(t, parent.ID, event.Data.(domain.Comment).ID)
+	event = receiveEvent(t, sub, domain.EventTypeComment)
+	require.Equal(t, reply.ID, event.Data.(domain.Comment).ID)
+
+	// parent with reply become tombstone, then removed along with reply
+	err = testService.Article().DeleteComment(ctx, port.DeleteCommentParams{AuthArg: userAuth, Slug: article.Slug, CommentID: parent.ID})
+	require.Nil(t, err)
+	err = testService.Article().DeleteComment(ctx, port.DeleteCommentParams{AuthArg: authorAuth, Slug: article.Slug, CommentID: reply.ID})
+	require.Nil(t, err)
+
+	deletedIDs := []domain.ID{}
+	for i := 0; i < 3; i++ {
+		event := receiveEvent(t, sub, domain.EventTypeCommentDeleted)
+		comment := event.Data.(domain.Comment)
+		require.True(t, comment.IsDeleted)
+		deletedIDs = append(deletedIDs, comment.ID)
+	}
+	require.Equal(t, []domain.ID{parent.ID, reply.ID, parent.ID}, deletedIDs)
+}
+
+// receiveEvent wait first event of type, skip others
+func receiveEvent(t *testing.T, sub port.EventSubscription, eventType string) domain.Event {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-sub.Events():
+			require.True(t, ok)
+			if event.Type == eventType {
+				return event
+			}
+		case <-timeout:
+			require.FailNow(t, "event not received", eventType)
+		}
+	}
+}
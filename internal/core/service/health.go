@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+)
+
+const healthCheckTimeout = 2 * time.Second
+
+type healthService struct {
+	property serviceProperty
+}
+
+func NewHealthService(property serviceProperty) port.HealthService {
+	return &healthService{
+		property: property,
+	}
+}
+
+func (s *healthService) Ready(ctx context.Context) (port.RepositoryHealth, error) {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	health, err := s.property.repo.Health(ctx)
+	if err != nil {
+		return health, exception.New(exception.TypeUnavailable, "database unavailable", err)
+	}
+	if health.MigrationDirty {
+		msg := fmt.Sprintf("migration %d is dirty", health.MigrationVersion)
+		return health, exception.New(exception.TypeUnavailable, msg, nil)
+	}
+	if health.MigrationVersion < health.LatestMigration {
+		msg := fmt.Sprintf("migration %d is behind latest %d", health.MigrationVersion, health.LatestMigration)
+		return health, exception.New(exception.TypeUnavailable, msg, nil)
+	}
+	return health, nil
+}
@@ -0,0 +1,16 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthReady(t *testing.T) {
+	health, err := testService.Health().Ready(context.Background())
+	require.Nil(t, err)
+	require.NotEmpty(t, health.Database)
+	require.False(t, health.MigrationDirty)
+	require.Equal(t, health.LatestMigration, health.MigrationVersion)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+)
+
+const (
+	DefaultIdempotencyTTL   = 24 * time.Hour
+	DefaultIdempotencyLease = time.Minute
+	idempotencyKeyMaxLen    = 255
+)
+
+type idempotencyService struct {
+	property serviceProperty
+	ttl      time.Duration
+	lease    time.Duration
+}
+
+func NewIdempotencyService(property serviceProperty) port.IdempotencyService {
+	ttl := property.config.IdempotencyTTL
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	lease := property.config.IdempotencyLease
+	if lease <= 0 {
+		lease = DefaultIdempotencyLease
+	}
+	return &idempotencyService{
+		property: property,
+		ttl:      ttl,
+		lease:    lease,
+	}
+}
+
+func (s *idempotencyService) Begin(ctx context.Context, arg port.BeginIdempotencyParams) (domain.Idempotency, bool, error) {
+	if arg.AuthArg.Payload == nil {
+		return domain.Idempotency{}, false, exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+	if arg.Key == "" || len(arg.Key) > idempotencyKeyMaxLen {
+		return domain.Idempotency{}, false, exception.Validation().AddError("idempotency_key", "must be 1 to 255 characters")
+	}
+
+	// second attempt after expired or released key removed,
+	// processing key expire after lease so key of crashed request is taken over
+	for attempt := 0; attempt < 2; attempt++ {
+		record := domain.NewIdempotency(domain.Idempotency{
+			UserID:      arg.AuthArg.Payload.UserID,
+			Key:         arg.Key,
+			RequestHash: arg.RequestHash,
+		}, s.lease)
+		added, err := s.property.repo.Idempotency().AddIdempotency(ctx, record)
+		if err != nil {
+			return domain.Idempotency{}, false, exception.Into(err)
+		}
+		if added {
+			return record, false, nil
+		}
+
+		existing, err := s.property.repo.Idempotency().FilterIdempotency(ctx, port.FilterIdempotencyPayload{
+			UserIDs: []domain.ID{arg.AuthArg.Payload.UserID},
+			Keys:    []string{arg.Key},
+		})
+		if err != nil {
+			return domain.Idempotency{}, false, exception.Into(err)
+		}
+		if len(existing) == 0 {
+			continue
+		}
+		current := existing[0]
+		if current.IsExpired() {
+			_, err := s.property.repo.Idempotency().DeleteIdempotency(ctx, port.FilterIdempotencyPayload{IDs: []domain.ID{current.ID}})
+			if err != nil {
+				return domain.Idempotency{}, false, exception.Into(err)
+			}
+			continue
+		}
+		if current.RequestHash != arg.RequestHash {
+			return domain.Idempotency{}, false, exception.Validation().AddError("idempotency_key", "already used for a different request")
+		}
+		if !current.IsCompleted() {
+			return domain.Idempotency{}, false, exception.New(exception.TypeConflict, "request with the idempotency key is still in progress", nil)
+		}
+		return current, true, nil
+	}
+	return domain.Idempotency{}, false, exception.New(exception.TypeConflict, "request with the idempotency key is still in progress", nil)
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, arg domain.Idempotency) error {
+	arg.Status = domain.IdempotencyStatusCompleted
+	arg.ExpiredAt = time.Now().Add(s.ttl)
+	_, err := s.property.repo.Idempotency().UpdateIdempotency(ctx, arg)
+	if err != nil {
+		return exception.Into(err)
+	}
+	return nil
+}
+
+func (s *idempotencyService) Release(ctx context.Context, arg domain.Idempotency) error {
+	_, err := s.property.repo.Idempotency().DeleteIdempotency(ctx, port.FilterIdempotencyPayload{IDs: []domain.ID{arg.ID}})
+	if err != nil {
+		return exception.Into(err)
+	}
+	return nil
+}
+
+func (s *idempotencyService) Purge(ctx context.Context) (int, error) {
+	count, err := s.property.repo.Idempotency().DeleteIdempotency(ctx, port.FilterIdempotencyPayload{ExpiredBefore: time.Now()})
+	if err != nil {
+		return 0, exception.Into(err)
+	}
+	return count, nil
+}
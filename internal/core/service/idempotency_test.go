@@ -0,0 +1,152 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/logger"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/service"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotency(t *testing.T) {
+	_, authArg, _ := createRandomUser(t)
+	ctx := context.Background()
+	arg := port.BeginIdempotencyParams{
+		AuthArg:     authArg,
+		Key:         util.RandomString(16),
+		RequestHash: domain.HashIdempotencyRequest([]byte("POST"), []byte("/articles"), []byte(util.RandomString(20))),
+	}
+
+	record, replay, err := testService.Idempotency().Begin(ctx, arg)
+	require.Nil(t, err)
+	require.False(t, replay)
+	require.Equal(t, domain.IdempotencyStatusProcessing, record.Status)
+
+	// retry while first request still running
+	_, _, err = testService.Idempotency().Begin(ctx, arg)
+	fail, ok := err.(*exception.Exception)
+	require.True(t, ok)
+	require.Equal(t, exception.TypeConflict, fail.Type)
+
+	record.ResponseCode = 201
+	record.Response = []byte(`{"article":{}}`)
+	require.Nil(t, testService.Idempotency().Complete(ctx, record))
+
+	result, replay, err := testService.Idempotency().Begin(ctx, arg)
+	require.Nil(t, err)
+	require.True(t, replay)
+	require.Equal(t, record.ID, result.ID)
+	require.Equal(t, 201, result.ResponseCode)
+	require.Equal(t, record.Response, result.Response)
+
+	// same key other request
+	other := arg
+	other.RequestHash = domain.HashIdempotencyRequest([]byte("POST"), []byte("/articles"), []byte(util.RandomString(20)))
+	_, _, err = testService.Idempotency().Begin(ctx, other)
+	fail, ok = err.(*exception.Exception)
+	require.True(t, ok)
+	require.Equal(t, exception.TypeValidation, fail.Type)
+
+	// other user has own keys
+	_, otherAuth, _ := createRandomUser(t)
+	other.AuthArg = otherAuth
+	_, replay, err = testService.Idempotency().Begin(ctx, other)
+	require.Nil(t, err)
+	require.False(t, replay)
+}
+
+func TestIdempotencyRelease(t *testing.T) {
+	_, authArg, _ := createRandomUser(t)
+	ctx := context.Background()
+	arg := port.BeginIdempotencyParams{
+		AuthArg:     authArg,
+		Key:         util.RandomString(16),
+		RequestHash: util.RandomString(32),
+	}
+
+	record, _, err := testService.Idempotency().Begin(ctx, arg)
+	require.Nil(t, err)
+	require.Nil(t, testService.Idempotency().Release(ctx, record))
+
+	result, replay, err := testService.Idempotency().Begin(ctx, arg)
+	require.Nil(t, err)
+	require.False(t, replay)
+	require.NotEqual(t, record.ID, result.ID)
+}
+
+func TestIdempotencyExpired(t *testing.T) {
+	config := testConfig
+	config.IdempotencyTTL = 50 * time.Millisecond
+	config.IdempotencyLease = 50 * time.Millisecond
+	svc, err := service.NewService(config, testRepo, logger.NewLogger(config))
+	require.Nil(t, err)
+
+	_, authArg, _ := createRandomUser(t)
+	ctx := context.Background()
+	arg := port.BeginIdempotencyParams{
+		AuthArg:     authArg,
+		Key:         util.RandomString(16),
+		RequestHash: util.RandomString(32),
+	}
+
+	record, _, err := svc.Idempotency().Begin(ctx, arg)
+	require.Nil(t, err)
+	record.ResponseCode = 201
+	require.Nil(t, svc.Idempotency().Complete(ctx, record))
+	time.Sleep(100 * time.Millisecond)
+
+	// expired key reused for new request
+	arg.RequestHash = util.RandomString(32)
+	result, replay, err := svc.Idempotency().Begin(ctx, arg)
+	require.Nil(t, err)
+	require.False(t, replay)
+	require.NotEqual(t, record.ID, result.ID)
+
+	time.Sleep(100 * time.Millisecond)
+	count, err := svc.Idempotency().Purge(ctx)
+	require.Nil(t, err)
+	require.GreaterOrEqual(t, count, 1)
+	records, err := testRepo.Idempotency().FilterIdempotency(ctx, port.FilterIdempotencyPayload{IDs: []domain.ID{result.ID}})
+	require.Nil(t, err)
+	require.Empty(t, records)
+}
+
+func TestIdempotencyStaleProcessing(t *testing.T) {
+	config := testConfig
+	config.IdempotencyLease = 50 * time.Millisecond
+	svc, err := service.NewService(config, testRepo, logger.NewLogger(config))
+	require.Nil(t, err)
+
+	_, authArg, _ := createRandomUser(t)
+	ctx := context.Background()
+	arg := port.BeginIdempotencyParams{
+		AuthArg:     authArg,
+		Key:         util.RandomString(16),
+		RequestHash: util.RandomString(32),
+	}
+
+	// first request never complete or release, such as process crashed
+	record, _, err := svc.Idempotency().Begin(ctx, arg)
+	require.Nil(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	result, replay, err := svc.Idempotency().Begin(ctx, arg)
+	require.Nil(t, err)
+	require.False(t, replay)
+	require.NotEqual(t, record.ID, result.ID)
+
+	// completed response kept for ttl, not lease
+	result.ResponseCode = 201
+	require.Nil(t, svc.Idempotency().Complete(ctx, result))
+	time.Sleep(100 * time.Millisecond)
+	replayed, replay, err := svc.Idempotency().Begin(ctx, arg)
+	require.Nil(t, err)
+	require.True(t, replay)
+	require.Equal(t, result.ID, replayed.ID)
+}
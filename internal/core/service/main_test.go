@@ -12,6 +12,7 @@ import (
 	"github.com/labasubagia/realworld-backend/internal/core/util"
 )
 
+var testConfig util.Config
 var testRepo port.Repository
 var testService port.Service
 
@@ -21,6 +22,7 @@ func TestMain(m *testing.M) {
 		fmt.Fprintln(os.Stderr, "failed to load config", err)
 		os.Exit(1)
 	}
+	testConfig = config
 	logger := logger.NewLogger(config)
 
 	var code int
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+)
+
+type notificationService struct {
+	property serviceProperty
+}
+
+func NewNotificationService(property serviceProperty) port.NotificationService {
+	return &notificationService{
+		property: property,
+	}
+}
+
+func (s *notificationService) List(ctx context.Context, arg port.ListNotificationParams) (result port.ListNotificationResult, err error) {
+	if arg.AuthArg.Payload == nil {
+		return port.ListNotificationResult{}, exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+
+	filter := port.FilterNotificationPayload{
+		UserIDs:    []domain.ID{arg.AuthArg.Payload.UserID},
+		UnreadOnly: true,
+	}
+	result.UnreadCount, err = s.property.repo.Notification().CountNotification(ctx, filter)
+	if err != nil {
+		return port.ListNotificationResult{}, exception.Into(err)
+	}
+	result.Count = result.UnreadCount
+	if !arg.UnreadOnly {
+		filter.UnreadOnly = false
+		result.Count, err = s.property.repo.Notification().CountNotification(ctx, filter)
+		if err != nil {
+			return port.ListNotificationResult{}, exception.Into(err)
+		}
+	}
+	if result.Count == 0 {
+		result.Notifications = []domain.Notification{}
+		return result, nil
+	}
+
+	filter.UnreadOnly = arg.UnreadOnly
+	filter.Limit = arg.Limit
+	filter.Offset = arg.Offset
+	notifications, err := s.property.repo.Notification().FilterNotification(ctx, filter)
+	if err != nil {
+		return port.ListNotificationResult{}, exception.Into(err)
+	}
+
+	result.Notifications, err = decorateNotifications(ctx, s.property.repo, notifications)
+	if err != nil {
+		return port.ListNotificationResult{}, exception.Into(err)
+	}
+
+	return result, nil
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, arg port.MarkNotificationReadParams) error {
+	if arg.AuthArg.Payload == nil {
+		return exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+	if len(arg.IDs) == 0 {
+		return exception.Validation().AddError("ids", "required")
+	}
+	err := s.property.repo.Notification().MarkNotificationRead(ctx, port.MarkNotificationReadPayload{
+		UserID: arg.AuthArg.Payload.UserID,
+		IDs:    arg.IDs,
+		ReadAt: time.Now(),
+	})
+	if err != nil {
+		return exception.Into(err)
+	}
+	return nil
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, arg port.AuthParams) error {
+	if arg.Payload == nil {
+		return exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+	err := s.property.repo.Notification().MarkNotificationRead(ctx, port.MarkNotificationReadPayload{
+		UserID: arg.Payload.UserID,
+		ReadAt: time.Now(),
+	})
+	if err != nil {
+		return exception.Into(err)
+	}
+	return nil
+}
+
+// decorateNotifications get notification actors and articles
+func decorateNotifications(ctx context.Context, r port.Repository, notifications []domain.Notification) ([]domain.Notification, error) {
+	if len(notifications) == 0 {
+		return notifications, nil
+	}
+
+	actorIDs := []domain.ID{}
+	articleIDs := []domain.ID{}
+	for _, notification := range notifications {
+		actorIDs = append(actorIDs, notification.ActorID)
+		if notification.ArticleID != "" {
+			articleIDs = append(articleIDs, notification.ArticleID)
+		}
+	}
+	actors, err := r.User().FilterUser(ctx, port.FilterUserPayload{IDs: actorIDs})
+	if err != nil {
+		return []domain.Notification{}, exception.Into(err)
+	}
+	actorMap := map[domain.ID]domain.User{}
+	for _, actor := range actors {
+		actorMap[actor.ID] = actor
+	}
+	articleMap := map[domain.ID]domain.Article{}
+	if len(articleIDs) > 0 {
+		articles, err := r.Article().FilterArticle(ctx, port.FilterArticlePayload{IDs: articleIDs})
+		if err != nil {
+			return []domain.Notification{}, exception.Into(err)
+		}
+		for _, article := range articles {
+			articleMap[article.ID] = article
+		}
+	}
+
+	for i, notification := range notifications {
+		notifications[i].Actor = actorMap[notification.ActorID]
+		notifications[i].Article = articleMap[notification.ArticleID]
+	}
+	return notifications, nil
+}
+
+// notify store notifications within transaction of repository r, skip notification of user own action.
+// Stored notifications are returned to be published after commit
+func (p serviceProperty) notify(ctx context.Context, r port.Repository, notifications ...domain.Notification) ([]domain.Notification, error) {
+	items := []domain.Notification{}
+	for _, notification := range notifications {
+		if notification.UserID == "" || notification.UserID == notification.ActorID {
+			continue
+		}
+		items = append(items, domain.NewNotification(notification))
+	}
+	if len(items) == 0 {
+		return []domain.Notification{}, nil
+	}
+	items, err := r.Notification().AddNotifications(ctx, items)
+	if err != nil {
+		return []domain.Notification{}, exception.Into(err)
+	}
+
+	items, err = decorateNotifications(ctx, r, items)
+	if err != nil {
+		return []domain.Notification{}, exception.Into(err)
+	}
+	return items, nil
+}
+
+// publishNotifications send notifications to recipients, call after transaction committed
+func (p serviceProperty) publishNotifications(notifications ...domain.Notification) {
+	for _, notification := range notifications {
+		p.publish(domain.Event{
+			Type:      domain.EventTypeNotification,
+			UserIDs:   []domain.ID{notification.UserID},
+			ArticleID: notification.ArticleID,
+			Data:      notification,
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotification(t *testing.T) {
+	author, authorAuth, _ := createRandomUser(t)
+	user, userAuth, _ := createRandomUser(t)
+	article := createRandomArticle(t, author, authorAuth)
+	ctx := context.Background()
+
+	listTypes := func(authArg port.AuthParams, unreadOnly bool) (port.ListNotificationResult, []string) {
+		result, err := testService.Notification().List(ctx, port.ListNotificationParams{
+			AuthArg:    authArg,
+			UnreadOnly: unreadOnly,
+			Limit:      10,
+		})
+		require.Nil(t, err)
+		types := []string{}
+		for _, notification := range result.Notifications {
+			require.False(t, notification.IsRead() && unreadOnly)
+			types = append(types, notification.Type)
+		}
+		return result, types
+	}
+
+	// user follow and favorite author article twice, notify once
+	for i := 0; i < 2; i++ {
+		_, err := testService.User().Follow(ctx, port.ProfileParams{AuthArg: userAuth, Username: author.Username})
+		require.Nil(t, err)
+		_, err = testService.Article().AddFavorite(ctx, port.AddFavoriteParams{AuthArg: userAuth, Slug: article.Slug})
+		require.Nil(t, err)
+	}
+
+	// user comment, author reply
+	comment, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: userAuth,
+		Slug:    article.Slug,
+		Comment: domain.Comment{Body: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+	authorComment, err := testService.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: authorAuth,
+		Slug:    article.Slug,
+		Comment: domain.Comment{ParentID: comment.ID, Body: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+
+	// user reply author comment on author article, only reply notified
+	_, err = testService.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: userAuth,
+		Slug:    article.Slug,
+		Comment: domain.Comment{ParentID: authorComment.ID, Body: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+
+	t.Run("Author", func(t *testing.T) {
+		result, types := listTypes(authorAuth, false)
+		require.Equal(t, []string{
+			domain.NotificationTypeReply,
+			domain.NotificationTypeComment,
+			domain.NotificationTypeFavorite,
+			domain.NotificationTypeFollow,
+		}, types)
+		require.Equal(t, 4, result.Count)
+		require.Equal(t, 4, result.UnreadCount)
+
+		for _, notification := range result.Notifications {
+			require.Equal(t, user.Username, notification.Actor.Username)
+			if notification.Type == domain.NotificationTypeFollow {
+				require.Empty(t, notification.ArticleID)
+				continue
+			}
+			require.Equal(t, article.Slug, notification.Article.Slug)
+		}
+	})
+
+	t.Run("User", func(t *testing.T) {
+		result, types := listTypes(userAuth, false)
+		require.Equal(t, []string{domain.NotificationTypeReply}, types)
+		require.Equal(t, authorComment.ID, result.Notifications[0].CommentID)
+	})
+
+	t.Run("Mark read", func(t *testing.T) {
+		result, _ := listTypes(authorAuth, true)
+		require.Len(t, result.Notifications, 4)
+
+		err := testService.Notification().MarkRead(ctx, port.MarkNotificationReadParams{
+			AuthArg: authorAuth,
+			IDs:     []domain.ID{result.Notifications[0].ID, result.Notifications[1].ID},
+		})
+		require.Nil(t, err)
+
+		// other user cannot mark author notification
+		err = testService.Notification().MarkRead(ctx, port.MarkNotificationReadParams{
+			AuthArg: userAuth,
+			IDs:     []domain.ID{result.Notifications[2].ID},
+		})
+		require.Nil(t, err)
+
+		result, types := listTypes(authorAuth, true)
+		require.Equal(t, []string{domain.NotificationTypeFavorite, domain.NotificationTypeFollow}, types)
+		require.Equal(t, 2, result.Count)
+		require.Equal(t, 2, result.UnreadCount)
+
+		result, _ = listTypes(authorAuth, false)
+		require.Equal(t, 4, result.Count)
+		require.Equal(t, 2, result.UnreadCount)
+		require.True(t, result.Notifications[0].IsRead())
+	})
+
+	t.Run("Mark all read", func(t *testing.T) {
+		err := testService.Notification().MarkAllRead(ctx, authorAuth)
+		require.Nil(t, err)
+
+		result, _ := listTypes(authorAuth, true)
+		require.Empty(t, result.Notifications)
+		require.Equal(t, 0, result.UnreadCount)
+
+		// user notification untouched
+		result, _ = listTypes(userAuth, true)
+		require.Len(t, result.Notifications, 1)
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		_, err := testService.Notification().List(ctx, port.ListNotificationParams{})
+		require.NotNil(t, err)
+		err = testService.Notification().MarkAllRead(ctx, port.AuthParams{})
+		require.NotNil(t, err)
+	})
+}
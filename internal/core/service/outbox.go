@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/labasubagia/realworld-backend/internal/core/util/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	DefaultOutboxPollInterval = time.Second
+	DefaultOutboxMaxAttempts  = 10
+	outboxBatchSize           = 100
+	outboxMaxBackoff          = 5 * time.Minute
+	outboxClaimLease          = 5 * time.Minute
+)
+
+// outboxRelay dispatch committed outbox events to registered handlers,
+// failed event is retried with exponential backoff until attempts run out
+type outboxRelay struct {
+	repo         port.Repository
+	logger       port.Logger
+	pollInterval time.Duration
+	maxAttempts  int
+
+	mu       sync.RWMutex
+	handlers map[string][]port.OutboxHandler
+}
+
+func newOutboxRelay(config util.Config, repo port.Repository, logger port.Logger) *outboxRelay {
+	relay := &outboxRelay{
+		repo:         repo,
+		logger:       logger,
+		pollInterval: config.OutboxPollInterval,
+		maxAttempts:  config.OutboxMaxAttempts,
+		handlers:     map[string][]port.OutboxHandler{},
+	}
+	if relay.pollInterval <= 0 {
+		relay.pollInterval = DefaultOutboxPollInterval
+	}
+	if relay.maxAttempts <= 0 {
+		relay.maxAttempts = DefaultOutboxMaxAttempts
+	}
+	return relay
+}
+
+func (o *outboxRelay) Register(eventType string, handler port.OutboxHandler) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.handlers[eventType] = append(o.handlers[eventType], handler)
+}
+
+func (o *outboxRelay) Process(ctx context.Context) (int, error) {
+	// claimed events are skipped by relay of other instances until lease end
+	now := time.Now()
+	events, err := o.repo.Outbox().ClaimOutboxEvents(ctx, port.ClaimOutboxPayload{
+		AvailableBefore: now,
+		LeaseUntil:      now.Add(outboxClaimLease),
+		Limit:           outboxBatchSize,
+	})
+	if err != nil {
+		return 0, exception.Into(err)
+	}
+
+	for _, event := range events {
+		// lease ended while earlier events dispatched, may be claimed again by other relay
+		if time.Now().After(event.AvailableAt) {
+			break
+		}
+		event.Attempts++
+		if err := o.dispatch(ctx, event); err != nil {
+			event.Error = err.Error()
+			if event.Attempts >= o.maxAttempts {
+				event.Status = domain.OutboxStatusFailed
+			} else {
+				event.AvailableAt = time.Now().Add(o.backoff(event.Attempts))
+			}
+			o.logger.Error().Err(err).Field("event_id", event.ID).Field("type", event.Type).Msg("failed to dispatch outbox event")
+		} else {
+			event.Status = domain.OutboxStatusProcessed
+			event.Error = ""
+			event.ProcessedAt = time.Now()
+		}
+		if _, err := o.repo.Outbox().UpdateOutboxEvent(ctx, event); err != nil {
+			return 0, exception.Into(err)
+		}
+	}
+	return len(events), nil
+}
+
+func (o *outboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+	for {
+		// drain backlog before wait next tick
+		for {
+			count, err := o.Process(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					o.logger.Error().Err(err).Msg("failed to process outbox")
+				}
+				break
+			}
+			if count < outboxBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch event to every handler, all handlers run again on retry
+func (o *outboxRelay) dispatch(ctx context.Context, event domain.OutboxEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "OutboxRelay.Dispatch",
+		attribute.String("outbox.event_id", event.ID.String()),
+		attribute.String("outbox.event_type", event.Type),
+		attribute.Int("outbox.attempts", event.Attempts),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	o.mu.RLock()
+	handlers := o.handlers[event.Type]
+	o.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (o *outboxRelay) backoff(attempts int) time.Duration {
+	backoff := o.pollInterval << (attempts - 1)
+	if backoff <= 0 || backoff > outboxMaxBackoff {
+		return outboxMaxBackoff
+	}
+	return backoff
+}
+
+// addOutbox write domain event within transaction of repository r,
+// same dedupe key written twice keep the first event
+func (p serviceProperty) addOutbox(ctx context.Context, r port.Repository, eventType, dedupeKey string, userID domain.ID, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return exception.Into(err)
+	}
+	err = r.Outbox().AddOutboxEvents(ctx, []domain.OutboxEvent{
+		domain.NewOutboxEvent(domain.OutboxEvent{
+			Type:      eventType,
+			DedupeKey: dedupeKey,
+			UserID:    userID,
+			Payload:   string(payload),
+		}),
+	})
+	if err != nil {
+		return exception.Into(err)
+	}
+	return nil
+}
+
+// outbox payload snapshot, decoupled from api serializer
+
+type eventProfile struct {
+	Username string `json:"username"`
+	Bio      string `json:"bio"`
+	Image    string `json:"image"`
+}
+
+type eventArticle struct {
+	Slug        string       `json:"slug"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Body        string       `json:"body"`
+	TagList     []string     `json:"tagList"`
+	Author      eventProfile `json:"author"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+}
+
+type eventComment struct {
+	ID        domain.ID    `json:"id"`
+	ParentID  domain.ID    `json:"parentId,omitempty"`
+	Body      string       `json:"body"`
+	Article   string       `json:"article"` // article slug
+	Author    eventProfile `json:"author"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+type eventFollow struct {
+	Follower eventProfile `json:"follower"`
+}
+
+func asEventProfile(user domain.User) eventProfile {
+	return eventProfile{
+		Username: user.Username,
+		Bio:      user.Bio,
+		Image:    user.Image,
+	}
+}
+
+func asEventArticle(article domain.Article, author domain.User, tags []string) eventArticle {
+	return eventArticle{
+		Slug:        article.Slug,
+		Title:       article.Title,
+		Description: article.Description,
+		Body:        article.Body,
+		TagList:     tags,
+		Author:      asEventProfile(author),
+		CreatedAt:   article.CreatedAt,
+		UpdatedAt:   article.UpdatedAt,
+	}
+}
+
+func asEventComment(article domain.Article, comment domain.Comment, author domain.User) eventComment {
+	return eventComment{
+		ID:        comment.ID,
+		ParentID:  comment.ParentID,
+		Body:      comment.Body,
+		Article:   article.Slug,
+		Author:    asEventProfile(author),
+		CreatedAt: comment.CreatedAt,
+	}
+}
+
+// articleSnapshot load article author and tags through repository r,
+// so snapshot read uncommitted change inside transaction
+func articleSnapshot(ctx context.Context, r port.Repository, article domain.Article) (eventArticle, error) {
+	author, err := r.User().FindOne(ctx, port.FilterUserPayload{IDs: []domain.ID{article.AuthorID}})
+	if err != nil {
+		return eventArticle{}, exception.Into(err)
+	}
+
+	tags := []string{}
+	articleTags, err := r.Article().FilterArticleTags(ctx, port.FilterArticleTagPayload{ArticleIDs: []domain.ID{article.ID}})
+	if err != nil {
+		return eventArticle{}, exception.Into(err)
+	}
+	if len(articleTags) > 0 {
+		tagIDs := []domain.ID{}
+		for _, articleTag := range articleTags {
+			tagIDs = append(tagIDs, articleTag.TagID)
+		}
+		result, err := r.Article().FilterTags(ctx, port.FilterTagPayload{IDs: tagIDs})
+		if err != nil {
+			return eventArticle{}, exception.Into(err)
+		}
+		for _, tag := range result {
+			tags = append(tags, tag.Name)
+		}
+		sort.Strings(tags)
+	}
+
+	return asEventArticle(article, author, tags), nil
+}
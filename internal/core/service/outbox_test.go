@@ -0,0 +1,222 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/logger"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/service"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+// findOutboxEvent get outbox event by dedupe key
+func findOutboxEvent(t *testing.T, dedupeKey string) domain.OutboxEvent {
+	events, err := testRepo.Outbox().FilterOutboxEvent(context.Background(), port.FilterOutboxPayload{
+		DedupeKeys: []string{dedupeKey},
+	})
+	require.Nil(t, err)
+	require.Len(t, events, 1)
+	return events[0]
+}
+
+func TestOutboxRelay(t *testing.T) {
+	config := testConfig
+	config.OutboxPollInterval = 10 * time.Millisecond
+	config.OutboxMaxAttempts = 2
+	svc, err := service.NewService(config, testRepo, logger.NewLogger(config))
+	require.Nil(t, err)
+
+	author, authorAuth, _ := createRandomUser(t)
+	ctx := context.Background()
+
+	// handler fail first attempt for author articles
+	var mu sync.Mutex
+	received := []domain.OutboxEvent{}
+	svc.Outbox().Register(domain.DomainEventArticleCreated, func(ctx context.Context, event domain.OutboxEvent) error {
+		if event.UserID != author.ID {
+			return nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, event)
+		if len(received) == 1 {
+			return errors.New("handler failed")
+		}
+		return nil
+	})
+	svc.Outbox().Register(domain.DomainEventArticleUpdated, func(ctx context.Context, event domain.OutboxEvent) error {
+		if event.UserID != author.ID {
+			return nil
+		}
+		return errors.New("always failed")
+	})
+
+	// event written with the change
+	article := createRandomArticle(t, author, authorAuth)
+	dedupeKey := domain.OutboxDedupeKey(domain.DomainEventArticleCreated, article.ID)
+	event := findOutboxEvent(t, dedupeKey)
+	require.Equal(t, domain.OutboxStatusPending, event.Status)
+	require.Equal(t, author.ID, event.UserID)
+
+	payload := struct {
+		Slug    string   `json:"slug"`
+		TagList []string `json:"tagList"`
+		Author  struct {
+			Username string `json:"username"`
+		} `json:"author"`
+	}{}
+	require.Nil(t, json.Unmarshal([]byte(event.Payload), &payload))
+	require.Equal(t, article.Slug, payload.Slug)
+	require.ElementsMatch(t, article.TagNames, payload.TagList)
+	require.Equal(t, author.Username, payload.Author.Username)
+
+	// failed attempt retried later
+	_, err = svc.Outbox().Process(ctx)
+	require.Nil(t, err)
+	event = findOutboxEvent(t, dedupeKey)
+	require.Equal(t, domain.OutboxStatusPending, event.Status)
+	require.Equal(t, 1, event.Attempts)
+	require.NotEmpty(t, event.Error)
+
+	require.Eventually(t, func() bool {
+		_, err := svc.Outbox().Process(ctx)
+		require.Nil(t, err)
+		return findOutboxEvent(t, dedupeKey).Status != domain.OutboxStatusPending
+	}, 5*time.Second, 10*time.Millisecond)
+	event = findOutboxEvent(t, dedupeKey)
+	require.Equal(t, domain.OutboxStatusProcessed, event.Status)
+	require.Equal(t, 2, event.Attempts)
+	require.Empty(t, event.Error)
+	require.False(t, event.ProcessedAt.IsZero())
+
+	mu.Lock()
+	require.Len(t, received, 2)
+	require.Equal(t, event.ID, received[1].ID)
+	mu.Unlock()
+
+	// same dedupe key is skipped
+	err = testRepo.Outbox().AddOutboxEvents(ctx, []domain.OutboxEvent{
+		domain.NewOutboxEvent(domain.OutboxEvent{
+			Type:      domain.DomainEventArticleCreated,
+			DedupeKey: dedupeKey,
+			UserID:    author.ID,
+			Payload:   "{}",
+		}),
+	})
+	require.Nil(t, err)
+	require.Equal(t, event.ID, findOutboxEvent(t, dedupeKey).ID)
+
+	// attempts run out
+	updated, err := svc.Article().Update(ctx, port.UpdateArticleParams{
+		AuthArg: authorAuth,
+		Slug:    article.Slug,
+		Article: domain.Article{Body: util.RandomString(20)},
+	})
+	require.Nil(t, err)
+	dedupeKey = domain.OutboxDedupeKey(domain.DomainEventArticleUpdated, updated.ID, updated.UpdatedAt.UnixNano())
+	require.Eventually(t, func() bool {
+		_, err := svc.Outbox().Process(ctx)
+		require.Nil(t, err)
+		return findOutboxEvent(t, dedupeKey).Status != domain.OutboxStatusPending
+	}, 5*time.Second, 10*time.Millisecond)
+	event = findOutboxEvent(t, dedupeKey)
+	require.Equal(t, domain.OutboxStatusFailed, event.Status)
+	require.Equal(t, 2, event.Attempts)
+}
+
+func TestOutboxAtomic(t *testing.T) {
+	user, _, _ := createRandomUser(t)
+	ctx := context.Background()
+
+	// failed change roll back its event, mongo need replica set
+	rollback := errors.New("rollback")
+	dedupeKey := domain.OutboxDedupeKey("test.rollback", user.ID)
+	err := testRepo.Atomic(ctx, func(r port.Repository) error {
+		if _, err := r.User().UpdateUser(ctx, domain.User{ID: user.ID, Bio: util.RandomString(20)}); err != nil {
+			return err
+		}
+		event := domain.NewOutboxEvent(domain.OutboxEvent{Type: "test.rollback", DedupeKey: dedupeKey, UserID: user.ID, Payload: `{}`})
+		if err := r.Outbox().AddOutboxEvents(ctx, []domain.OutboxEvent{event}); err != nil {
+			return err
+		}
+		return rollback
+	})
+	require.NotNil(t, err)
+	events, err := testRepo.Outbox().FilterOutboxEvent(ctx, port.FilterOutboxPayload{DedupeKeys: []string{dedupeKey}})
+	require.Nil(t, err)
+	require.Empty(t, events)
+	current, err := testRepo.User().FindOne(ctx, port.FilterUserPayload{IDs: []domain.ID{user.ID}})
+	require.Nil(t, err)
+	require.Equal(t, user.Bio, current.Bio)
+
+	// committed change keep its event
+	dedupeKey = domain.OutboxDedupeKey("test.commit", user.ID)
+	err = testRepo.Atomic(ctx, func(r port.Repository) error {
+		event := domain.NewOutboxEvent(domain.OutboxEvent{Type: "test.commit", DedupeKey: dedupeKey, UserID: user.ID, Payload: `{}`})
+		return r.Outbox().AddOutboxEvents(ctx, []domain.OutboxEvent{event})
+	})
+	require.Nil(t, err)
+	findOutboxEvent(t, dedupeKey)
+}
+
+func TestOutboxClaim(t *testing.T) {
+	user, _, _ := createRandomUser(t)
+	ctx := context.Background()
+
+	eventType := "test.claim." + util.RandomString(8)
+	events := []domain.OutboxEvent{}
+	for i := 0; i < 20; i++ {
+		events = append(events, domain.NewOutboxEvent(domain.OutboxEvent{
+			Type:      eventType,
+			DedupeKey: domain.OutboxDedupeKey(eventType, i),
+			UserID:    user.ID,
+			Payload:   `{}`,
+		}))
+	}
+	require.Nil(t, testRepo.Outbox().AddOutboxEvents(ctx, events))
+
+	// relays of two instances share the database
+	var mu sync.Mutex
+	dispatched := map[domain.ID]int{}
+	relays := []port.Service{}
+	for i := 0; i < 2; i++ {
+		svc, err := service.NewService(testConfig, testRepo, logger.NewLogger(testConfig))
+		require.Nil(t, err)
+		svc.Outbox().Register(eventType, func(ctx context.Context, event domain.OutboxEvent) error {
+			mu.Lock()
+			defer mu.Unlock()
+			dispatched[event.ID]++
+			return nil
+		})
+		relays = append(relays, svc)
+	}
+
+	var wg sync.WaitGroup
+	for _, svc := range relays {
+		wg.Add(1)
+		go func(svc port.Service) {
+			defer wg.Done()
+			// drain, backlog of other tests may come first
+			for {
+				count, err := svc.Outbox().Process(ctx)
+				require.Nil(t, err)
+				if count == 0 {
+					return
+				}
+			}
+		}(svc)
+	}
+	wg.Wait()
+
+	require.Len(t, dispatched, len(events))
+	for _, count := range dispatched {
+		require.Equal(t, 1, count)
+	}
+}
@@ -1,8 +1,10 @@
 package service
 
 import (
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
 	"github.com/labasubagia/realworld-backend/internal/core/port"
 	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/pubsub"
 	"github.com/labasubagia/realworld-backend/internal/core/util/token"
 )
 
@@ -11,12 +13,20 @@ type serviceProperty struct {
 	tokenMaker token.Maker
 	repo       port.Repository
 	logger     port.Logger
+	broker     *pubsub.Broker
+	webhook    *webhookDispatcher
+	outbox     *outboxRelay
 }
 
 type services struct {
-	property       serviceProperty
-	articleService port.ArticleService
-	userService    port.UserService
+	property            serviceProperty
+	articleService      port.ArticleService
+	userService         port.UserService
+	notificationService port.NotificationService
+	eventService        port.EventService
+	webhookService      port.WebhookService
+	idempotencyService  port.IdempotencyService
+	healthService       port.HealthService
 }
 
 func NewService(config util.Config, repo port.Repository, logger port.Logger) (port.Service, error) {
@@ -29,11 +39,24 @@ func NewService(config util.Config, repo port.Repository, logger port.Logger) (p
 		repo:       repo,
 		tokenMaker: tokenMaker,
 		logger:     logger,
+		broker:     pubsub.NewBroker(pubsub.DefaultHistorySize, pubsub.DefaultBufferSize),
+		webhook:    newWebhookDispatcher(config, repo, logger),
+		outbox:     newOutboxRelay(config, repo, logger),
 	}
+	for _, event := range domain.WebhookEvents {
+		property.outbox.Register(event, property.webhook.handleOutbox)
+	}
+	property.outbox.Register(domain.WebhookRedeliverEvent, property.webhook.handleRedeliver)
+
 	svc := services{
-		property:       property,
-		articleService: NewArticleService(property),
-		userService:    NewUserService(property),
+		property:            property,
+		articleService:      &tracedArticleService{next: NewArticleService(property)},
+		userService:         &tracedUserService{next: NewUserService(property)},
+		notificationService: &tracedNotificationService{next: NewNotificationService(property)},
+		eventService:        &tracedEventService{next: NewEventService(property)},
+		webhookService:      &tracedWebhookService{next: NewWebhookService(property)},
+		idempotencyService:  &tracedIdempotencyService{next: NewIdempotencyService(property)},
+		healthService:       NewHealthService(property),
 	}
 	return &svc, nil
 }
@@ -49,3 +72,27 @@ func (s *services) Article() port.ArticleService {
 func (s *services) User() port.UserService {
 	return s.userService
 }
+
+func (s *services) Notification() port.NotificationService {
+	return s.notificationService
+}
+
+func (s *services) Event() port.EventService {
+	return s.eventService
+}
+
+func (s *services) Webhook() port.WebhookService {
+	return s.webhookService
+}
+
+func (s *services) Outbox() port.OutboxRelay {
+	return s.property.outbox
+}
+
+func (s *services) Idempotency() port.IdempotencyService {
+	return s.idempotencyService
+}
+
+func (s *services) Health() port.HealthService {
+	return s.healthService
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util/tracing"
+)
+
+// traced services start span of every service method,
+// repository queries run inside become its child spans
+
+type tracedUserService struct {
+	next port.UserService
+}
+
+func (s *tracedUserService) Register(ctx context.Context, arg port.RegisterParams) (domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Register")
+	result, err := s.next.Register(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedUserService) Login(ctx context.Context, arg port.LoginParams) (domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	result, err := s.next.Login(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedUserService) Update(ctx context.Context, arg port.UpdateUserParams) (domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Update")
+	result, err := s.next.Update(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedUserService) Current(ctx context.Context, arg port.AuthParams) (domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Current")
+	result, err := s.next.Current(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedUserService) Profile(ctx context.Context, arg port.ProfileParams) (domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Profile")
+	result, err := s.next.Profile(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedUserService) Follow(ctx context.Context, arg port.ProfileParams) (domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Follow")
+	result, err := s.next.Follow(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedUserService) UnFollow(ctx context.Context, arg port.ProfileParams) (domain.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UnFollow")
+	result, err := s.next.UnFollow(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+type tracedArticleService struct {
+	next port.ArticleService
+}
+
+func (s *tracedArticleService) Create(ctx context.Context, arg port.CreateArticleTxParams) (domain.Article, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.Create")
+	result, err := s.next.Create(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) Update(ctx context.Context, arg port.UpdateArticleParams) (domain.Article, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.Update")
+	result, err := s.next.Update(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) Delete(ctx context.Context, arg port.DeleteArticleParams) error {
+	ctx, span := tracing.Start(ctx, "ArticleService.Delete")
+	err := s.next.Delete(ctx, arg)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedArticleService) List(ctx context.Context, arg port.ListArticleParams) (port.ListArticleResult, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.List")
+	result, err := s.next.List(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) Feed(ctx context.Context, arg port.ListArticleParams) (port.ListArticleResult, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.Feed")
+	result, err := s.next.Feed(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) Get(ctx context.Context, arg port.GetArticleParams) (domain.Article, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.Get")
+	result, err := s.next.Get(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) AddComment(ctx context.Context, arg port.AddCommentParams) (domain.Comment, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.AddComment")
+	result, err := s.next.AddComment(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) ListComments(ctx context.Context, arg port.ListCommentParams) (port.ListCommentResult, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.ListComments")
+	result, err := s.next.ListComments(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) UpdateComment(ctx context.Context, arg port.UpdateCommentParams) (domain.Comment, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.UpdateComment")
+	result, err := s.next.UpdateComment(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) DeleteComment(ctx context.Context, arg port.DeleteCommentParams) error {
+	ctx, span := tracing.Start(ctx, "ArticleService.DeleteComment")
+	err := s.next.DeleteComment(ctx, arg)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedArticleService) ListCommentRevisions(ctx context.Context, arg port.ListCommentRevisionParams) ([]domain.CommentRevision, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.ListCommentRevisions")
+	result, err := s.next.ListCommentRevisions(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) ListMentions(ctx context.Context, arg port.ListMentionParams) ([]domain.Mention, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.ListMentions")
+	result, err := s.next.ListMentions(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) AddFavorite(ctx context.Context, arg port.AddFavoriteParams) (domain.Article, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.AddFavorite")
+	result, err := s.next.AddFavorite(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) RemoveFavorite(ctx context.Context, arg port.RemoveFavoriteParams) (domain.Article, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.RemoveFavorite")
+	result, err := s.next.RemoveFavorite(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedArticleService) ListTags(ctx context.Context) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "ArticleService.ListTags")
+	result, err := s.next.ListTags(ctx)
+	tracing.End(span, err)
+	return result, err
+}
+
+type tracedNotificationService struct {
+	next port.NotificationService
+}
+
+func (s *tracedNotificationService) List(ctx context.Context, arg port.ListNotificationParams) (port.ListNotificationResult, error) {
+	ctx, span := tracing.Start(ctx, "NotificationService.List")
+	result, err := s.next.List(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedNotificationService) MarkRead(ctx context.Context, arg port.MarkNotificationReadParams) error {
+	ctx, span := tracing.Start(ctx, "NotificationService.MarkRead")
+	err := s.next.MarkRead(ctx, arg)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedNotificationService) MarkAllRead(ctx context.Context, arg port.AuthParams) error {
+	ctx, span := tracing.Start(ctx, "NotificationService.MarkAllRead")
+	err := s.next.MarkAllRead(ctx, arg)
+	tracing.End(span, err)
+	return err
+}
+
+type tracedEventService struct {
+	next port.EventService
+}
+
+func (s *tracedEventService) Subscribe(ctx context.Context, arg port.SubscribeEventParams) (port.EventSubscription, error) {
+	ctx, span := tracing.Start(ctx, "EventService.Subscribe")
+	result, err := s.next.Subscribe(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedEventService) SubscribeComments(ctx context.Context, arg port.SubscribeCommentParams) (port.EventSubscription, error) {
+	ctx, span := tracing.Start(ctx, "EventService.SubscribeComments")
+	result, err := s.next.SubscribeComments(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+type tracedWebhookService struct {
+	next port.WebhookService
+}
+
+func (s *tracedWebhookService) Create(ctx context.Context, arg port.CreateWebhookParams) (domain.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Create")
+	result, err := s.next.Create(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedWebhookService) List(ctx context.Context, arg port.AuthParams) ([]domain.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.List")
+	result, err := s.next.List(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedWebhookService) Delete(ctx context.Context, arg port.DeleteWebhookParams) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.Delete")
+	err := s.next.Delete(ctx, arg)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedWebhookService) ListDeliveries(ctx context.Context, arg port.ListWebhookDeliveryParams) ([]domain.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.ListDeliveries")
+	result, err := s.next.ListDeliveries(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (s *tracedWebhookService) Redeliver(ctx context.Context, arg port.RedeliverWebhookParams) (domain.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Redeliver")
+	result, err := s.next.Redeliver(ctx, arg)
+	tracing.End(span, err)
+	return result, err
+}
+
+type tracedIdempotencyService struct {
+	next port.IdempotencyService
+}
+
+func (s *tracedIdempotencyService) Begin(ctx context.Context, arg port.BeginIdempotencyParams) (domain.Idempotency, bool, error) {
+	ctx, span := tracing.Start(ctx, "IdempotencyService.Begin")
+	result, ok, err := s.next.Begin(ctx, arg)
+	tracing.End(span, err)
+	return result, ok, err
+}
+
+func (s *tracedIdempotencyService) Complete(ctx context.Context, arg domain.Idempotency) error {
+	ctx, span := tracing.Start(ctx, "IdempotencyService.Complete")
+	err := s.next.Complete(ctx, arg)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedIdempotencyService) Release(ctx context.Context, arg domain.Idempotency) error {
+	ctx, span := tracing.Start(ctx, "IdempotencyService.Release")
+	err := s.next.Release(ctx, arg)
+	tracing.End(span, err)
+	return err
+}
+
+func (s *tracedIdempotencyService) Purge(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "IdempotencyService.Purge")
+	result, err := s.next.Purge(ctx)
+	tracing.End(span, err)
+	return result, err
+}
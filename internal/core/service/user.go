@@ -8,6 +8,7 @@ import (
 	"github.com/labasubagia/realworld-backend/internal/core/port"
 	"github.com/labasubagia/realworld-backend/internal/core/util"
 	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/labasubagia/realworld-backend/internal/core/util/metrics"
 )
 
 type userService struct {
@@ -29,6 +30,7 @@ func (s *userService) Register(ctx context.Context, req port.RegisterParams) (us
 	if err != nil {
 		return domain.User{}, exception.Into(err)
 	}
+	metrics.UserRegistered()
 
 	user.Token, _, err = s.property.tokenMaker.CreateToken(user.ID, 2*time.Hour)
 	if err != nil {
@@ -93,6 +95,7 @@ func (s *userService) Update(ctx context.Context, arg port.UpdateUserParams) (us
 		Image:     arg.User.Image,
 		Bio:       arg.User.Bio,
 		UpdatedAt: time.Now(),
+		Version:   arg.User.Version, // conditional update when provided
 	}
 	if arg.User.Password != "" {
 		if err := payload.SetPassword(arg.User.Password); err != nil {
@@ -162,10 +165,32 @@ func (s *userService) Follow(ctx context.Context, arg port.ProfileParams) (user
 	if err != nil {
 		return domain.User{}, exception.Into(err)
 	}
-	_, err = s.property.repo.User().Follow(ctx, newFollow)
+	var notifications []domain.Notification
+	err = s.property.repo.Atomic(ctx, func(r port.Repository) error {
+		if _, err := r.User().Follow(ctx, newFollow); err != nil {
+			return exception.Into(err)
+		}
+		notifications, err = s.property.notify(ctx, r, domain.Notification{
+			UserID:  newFollow.FolloweeID,
+			ActorID: newFollow.FollowerID,
+			Type:    domain.NotificationTypeFollow,
+		})
+		if err != nil {
+			return exception.Into(err)
+		}
+
+		follower, err := r.User().FindOne(ctx, port.FilterUserPayload{IDs: []domain.ID{newFollow.FollowerID}})
+		if err != nil {
+			return exception.Into(err)
+		}
+		// follow again after unfollow is a new follow row, so a new event
+		dedupeKey := domain.OutboxDedupeKey(domain.DomainEventUserFollowed, newFollow.FollowerID, newFollow.FolloweeID, newFollow.CreatedAt.UnixNano())
+		return s.property.addOutbox(ctx, r, domain.DomainEventUserFollowed, dedupeKey, newFollow.FolloweeID, eventFollow{Follower: asEventProfile(follower)})
+	})
 	if err != nil {
 		return domain.User{}, exception.Into(err)
 	}
+	s.property.publishNotifications(notifications...)
 
 	user.IsFollowed = true
 	return user, nil
@@ -122,6 +122,37 @@ func TestUpdateUserEmptyOK(t *testing.T) {
 	require.Nil(t, util.CheckPassword(password, result.Password))
 }
 
+func TestUpdateUserVersion(t *testing.T) {
+	user, authArg, _ := createRandomUser(t)
+	ctx := context.Background()
+	require.Equal(t, 1, user.Version)
+
+	result, err := testService.User().Update(ctx, port.UpdateUserParams{
+		AuthArg: authArg,
+		User:    domain.User{ID: user.ID, Bio: util.RandomString(10), Version: user.Version},
+	})
+	require.Nil(t, err)
+	require.Equal(t, user.Version+1, result.Version)
+
+	// stale version conflict
+	_, err = testService.User().Update(ctx, port.UpdateUserParams{
+		AuthArg: authArg,
+		User:    domain.User{ID: user.ID, Bio: util.RandomString(10), Version: user.Version},
+	})
+	require.NotNil(t, err)
+	fail, ok := err.(*exception.Exception)
+	require.True(t, ok)
+	require.Equal(t, exception.TypeConflict, fail.Type)
+
+	// without version always update
+	result, err = testService.User().Update(ctx, port.UpdateUserParams{
+		AuthArg: authArg,
+		User:    domain.User{ID: user.ID, Bio: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+	require.Equal(t, user.Version+2, result.Version)
+}
+
 func TestProfile(t *testing.T) {
 	user, authArg, _ := createRandomUser(t)
 	result, err := testService.User().Profile(context.Background(), port.ProfileParams{
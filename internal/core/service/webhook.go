@@ -0,0 +1,372 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+)
+
+const (
+	DefaultWebhookMaxAttempts = 5
+	webhookRequestTimeout     = 10 * time.Second
+	webhookResponseReadLimit  = 64 * 1024
+)
+
+type webhookService struct {
+	property serviceProperty
+}
+
+func NewWebhookService(property serviceProperty) port.WebhookService {
+	return &webhookService{
+		property: property,
+	}
+}
+
+func (s *webhookService) Create(ctx context.Context, arg port.CreateWebhookParams) (domain.Webhook, error) {
+	if arg.AuthArg.Payload == nil {
+		return domain.Webhook{}, exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+	arg.Webhook.UserID = arg.AuthArg.Payload.UserID
+	webhook, err := domain.NewWebhook(arg.Webhook)
+	if err != nil {
+		return domain.Webhook{}, exception.Into(err)
+	}
+	// dialer check again on delivery, host may resolve differently later
+	if !s.property.config.WebhookAllowPrivate {
+		parsed, _ := url.Parse(webhook.URL)
+		if err := util.ValidatePublicHost(ctx, parsed.Hostname()); err != nil {
+			return domain.Webhook{}, exception.Validation().AddError("url", err.Error())
+		}
+	}
+	webhook, err = s.property.repo.Webhook().CreateWebhook(ctx, webhook)
+	if err != nil {
+		return domain.Webhook{}, exception.Into(err)
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) List(ctx context.Context, arg port.AuthParams) ([]domain.Webhook, error) {
+	if arg.Payload == nil {
+		return []domain.Webhook{}, exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+	webhooks, err := s.property.repo.Webhook().FilterWebhook(ctx, port.FilterWebhookPayload{
+		UserIDs: []domain.ID{arg.Payload.UserID},
+	})
+	if err != nil {
+		return []domain.Webhook{}, exception.Into(err)
+	}
+	return webhooks, nil
+}
+
+func (s *webhookService) Delete(ctx context.Context, arg port.DeleteWebhookParams) error {
+	webhook, err := s.findWebhook(ctx, arg.AuthArg, arg.WebhookID)
+	if err != nil {
+		return exception.Into(err)
+	}
+	if err := s.property.repo.Webhook().DeleteWebhook(ctx, webhook); err != nil {
+		return exception.Into(err)
+	}
+	return nil
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, arg port.ListWebhookDeliveryParams) ([]domain.WebhookDelivery, error) {
+	webhook, err := s.findWebhook(ctx, arg.AuthArg, arg.WebhookID)
+	if err != nil {
+		return []domain.WebhookDelivery{}, exception.Into(err)
+	}
+	deliveries, err := s.property.repo.Webhook().FilterWebhookDelivery(ctx, port.FilterWebhookDeliveryPayload{
+		WebhookIDs: []domain.ID{webhook.ID},
+		Limit:      arg.Limit,
+		Offset:     arg.Offset,
+	})
+	if err != nil {
+		return []domain.WebhookDelivery{}, exception.Into(err)
+	}
+	return deliveries, nil
+}
+
+// Redeliver resend failed delivery with same payload, attempts continue from previous
+func (s *webhookService) Redeliver(ctx context.Context, arg port.RedeliverWebhookParams) (domain.WebhookDelivery, error) {
+	webhook, err := s.findWebhook(ctx, arg.AuthArg, arg.WebhookID)
+	if err != nil {
+		return domain.WebhookDelivery{}, exception.Into(err)
+	}
+	deliveries, err := s.property.repo.Webhook().FilterWebhookDelivery(ctx, port.FilterWebhookDeliveryPayload{
+		IDs:        []domain.ID{arg.DeliveryID},
+		WebhookIDs: []domain.ID{webhook.ID},
+	})
+	if err != nil {
+		return domain.WebhookDelivery{}, exception.Into(err)
+	}
+	if len(deliveries) == 0 {
+		return domain.WebhookDelivery{}, exception.New(exception.TypeNotFound, "webhook delivery not found", nil)
+	}
+	delivery := deliveries[0]
+	if !delivery.IsFailed() {
+		return domain.WebhookDelivery{}, exception.Validation().AddError("delivery", "only failed delivery can be redelivered")
+	}
+
+	delivery.Status = domain.WebhookDeliveryPending
+	delivery.UpdatedAt = time.Now()
+	// relay send it after commit, so redelivery survive restart
+	err = s.property.repo.Atomic(ctx, func(r port.Repository) error {
+		delivery, err = r.Webhook().UpdateWebhookDelivery(ctx, delivery)
+		if err != nil {
+			return exception.Into(err)
+		}
+		dedupeKey := domain.OutboxDedupeKey(domain.WebhookRedeliverEvent, delivery.ID, delivery.Attempts)
+		return s.property.addOutbox(ctx, r, domain.WebhookRedeliverEvent, dedupeKey, webhook.UserID, webhookRedeliver{DeliveryID: delivery.ID})
+	})
+	if err != nil {
+		return domain.WebhookDelivery{}, exception.Into(err)
+	}
+
+	return delivery, nil
+}
+
+// findWebhook get webhook owned by authenticated user
+func (s *webhookService) findWebhook(ctx context.Context, authArg port.AuthParams, webhookID domain.ID) (domain.Webhook, error) {
+	if authArg.Payload == nil {
+		return domain.Webhook{}, exception.New(exception.TypePermissionDenied, "authentication required", nil)
+	}
+	webhooks, err := s.property.repo.Webhook().FilterWebhook(ctx, port.FilterWebhookPayload{
+		IDs:     []domain.ID{webhookID},
+		UserIDs: []domain.ID{authArg.Payload.UserID},
+	})
+	if err != nil {
+		return domain.Webhook{}, exception.Into(err)
+	}
+	if len(webhooks) == 0 {
+		return domain.Webhook{}, exception.New(exception.TypeNotFound, "webhook not found", nil)
+	}
+	return webhooks[0], nil
+}
+
+// handleOutbox send event to owner webhooks subscribed to it, one attempt per relay attempt.
+// Error is returned while a delivery is still pending so the relay retry the event,
+// delivery finished on earlier attempt is skipped
+func (d *webhookDispatcher) handleOutbox(ctx context.Context, event domain.OutboxEvent) error {
+	webhooks, err := d.repo.Webhook().FilterWebhook(ctx, port.FilterWebhookPayload{
+		UserIDs: []domain.ID{event.UserID},
+		Events:  []string{event.Type},
+	})
+	if err != nil {
+		return exception.Into(err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	webhookIDs := []domain.ID{}
+	for _, webhook := range webhooks {
+		webhookIDs = append(webhookIDs, webhook.ID)
+	}
+	deliveries, err := d.repo.Webhook().FilterWebhookDelivery(ctx, port.FilterWebhookDeliveryPayload{
+		WebhookIDs: webhookIDs,
+		EventIDs:   []domain.ID{event.ID},
+	})
+	if err != nil {
+		return exception.Into(err)
+	}
+	existing := map[domain.ID]domain.WebhookDelivery{}
+	for _, delivery := range deliveries {
+		existing[delivery.WebhookID] = delivery
+	}
+
+	var errs []error
+	for _, webhook := range webhooks {
+		delivery, ok := existing[webhook.ID]
+		if !ok {
+			delivery = domain.NewWebhookDelivery(domain.WebhookDelivery{
+				WebhookID: webhook.ID,
+				EventID:   event.ID,
+				Event:     event.Type,
+			})
+			payload, err := json.Marshal(webhookPayload{
+				ID:        delivery.ID,
+				Event:     event.Type,
+				CreatedAt: delivery.CreatedAt,
+				Data:      json.RawMessage(event.Payload),
+			})
+			if err != nil {
+				return exception.Into(err)
+			}
+			delivery.Payload = string(payload)
+
+			delivery, err = d.repo.Webhook().AddWebhookDelivery(ctx, delivery)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		if delivery.Status != domain.WebhookDeliveryPending {
+			continue
+		}
+		if err := d.deliver(ctx, webhook, delivery, event.Attempts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// handleRedeliver send delivery reset to pending by Redeliver
+func (d *webhookDispatcher) handleRedeliver(ctx context.Context, event domain.OutboxEvent) error {
+	var payload webhookRedeliver
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+		return exception.Into(err)
+	}
+	deliveries, err := d.repo.Webhook().FilterWebhookDelivery(ctx, port.FilterWebhookDeliveryPayload{
+		IDs: []domain.ID{payload.DeliveryID},
+	})
+	if err != nil {
+		return exception.Into(err)
+	}
+	if len(deliveries) == 0 || deliveries[0].Status != domain.WebhookDeliveryPending {
+		return nil
+	}
+	delivery := deliveries[0]
+
+	webhooks, err := d.repo.Webhook().FilterWebhook(ctx, port.FilterWebhookPayload{
+		IDs: []domain.ID{delivery.WebhookID},
+	})
+	if err != nil {
+		return exception.Into(err)
+	}
+	if len(webhooks) == 0 {
+		return nil // webhook deleted
+	}
+	return d.deliver(ctx, webhooks[0], delivery, event.Attempts)
+}
+
+// webhookDispatcher send deliveries of outbox event, the relay retry failed attempt with backoff
+type webhookDispatcher struct {
+	repo        port.Repository
+	logger      port.Logger
+	client      *http.Client
+	maxAttempts int
+}
+
+func newWebhookDispatcher(config util.Config, repo port.Repository, logger port.Logger) *webhookDispatcher {
+	dispatcher := &webhookDispatcher{
+		repo:        repo,
+		logger:      logger,
+		client:      newWebhookClient(config.WebhookAllowPrivate),
+		maxAttempts: config.WebhookMaxAttempts,
+	}
+	if dispatcher.maxAttempts <= 0 {
+		dispatcher.maxAttempts = DefaultWebhookMaxAttempts
+	}
+	// delivery must be finished before relay give up the event
+	outboxMaxAttempts := config.OutboxMaxAttempts
+	if outboxMaxAttempts <= 0 {
+		outboxMaxAttempts = DefaultOutboxMaxAttempts
+	}
+	if dispatcher.maxAttempts > outboxMaxAttempts {
+		dispatcher.maxAttempts = outboxMaxAttempts
+	}
+	return dispatcher
+}
+
+// newWebhookClient refuse to connect private address unless allowed,
+// checked on dialed address so dns rebinding after create and redirect are caught
+func newWebhookClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: webhookRequestTimeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !util.IsPublicIP(net.ParseIP(host)) {
+				return fmt.Errorf("webhook address %s is not public", host)
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // proxy would hide dialed address
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   webhookRequestTimeout,
+		Transport: transport,
+	}
+}
+
+// deliver send delivery once and record the attempt,
+// error is returned while delivery still pending for next relay attempt
+func (d *webhookDispatcher) deliver(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery, attempt int) error {
+	code, sendErr := d.send(ctx, webhook, delivery)
+	if ctx.Err() != nil {
+		// interrupted by shutdown, not an attempt, delivery stay pending for next start
+		return ctx.Err()
+	}
+
+	delivery.Attempts++
+	delivery.ResponseCode = code
+	delivery.UpdatedAt = time.Now()
+	if sendErr == nil {
+		delivery.Status = domain.WebhookDeliverySuccess
+		delivery.Error = ""
+	} else {
+		delivery.Error = sendErr.Error()
+		if attempt >= d.maxAttempts {
+			delivery.Status = domain.WebhookDeliveryFailed
+		}
+	}
+
+	if _, err := d.repo.Webhook().UpdateWebhookDelivery(ctx, delivery); err != nil {
+		return exception.Into(err)
+	}
+	if delivery.Status == domain.WebhookDeliveryPending {
+		return sendErr
+	}
+	return nil
+}
+
+func (d *webhookDispatcher) send(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery) (int, error) {
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(domain.WebhookEventHeader, delivery.Event)
+	req.Header.Set(domain.WebhookDeliveryHeader, delivery.ID.String())
+	req.Header.Set(domain.WebhookSignatureHeader, webhook.Sign(payload))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, webhookResponseReadLimit))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+type webhookPayload struct {
+	ID        domain.ID       `json:"id"`
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type webhookRedeliver struct {
+	DeliveryID domain.ID `json:"deliveryId"`
+}
@@ -0,0 +1,262 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/adapter/logger"
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/labasubagia/realworld-backend/internal/core/port"
+	"github.com/labasubagia/realworld-backend/internal/core/service"
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookReceiver record requests, respond with status from statuses in order then last one
+func webhookReceiver(t *testing.T, statuses ...int) (*httptest.Server, func() []webhookRequest) {
+	var mu sync.Mutex
+	requests := []webhookRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+
+		mu.Lock()
+		requests = append(requests, webhookRequest{header: r.Header.Clone(), body: body})
+		status := statuses[len(statuses)-1]
+		if len(requests) <= len(statuses) {
+			status = statuses[len(requests)-1]
+		}
+		mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []webhookRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]webhookRequest{}, requests...)
+	}
+}
+
+// waitDelivery relay outbox and wait until latest delivery of webhook finished
+func waitDelivery(t *testing.T, svc port.Service, authArg port.AuthParams, webhookID domain.ID) domain.WebhookDelivery {
+	var delivery domain.WebhookDelivery
+	require.Eventually(t, func() bool {
+		_, err := svc.Outbox().Process(context.Background())
+		require.Nil(t, err)
+		deliveries, err := svc.Webhook().ListDeliveries(context.Background(), port.ListWebhookDeliveryParams{
+			AuthArg:   authArg,
+			WebhookID: webhookID,
+			Limit:     1,
+		})
+		require.Nil(t, err)
+		if len(deliveries) == 0 {
+			return false
+		}
+		delivery = deliveries[0]
+		return delivery.Status != domain.WebhookDeliveryPending
+	}, 5*time.Second, 10*time.Millisecond)
+	return delivery
+}
+
+func TestCreateWebhook(t *testing.T) {
+	_, authArg, _ := createRandomUser(t)
+	ctx := context.Background()
+
+	_, err := testService.Webhook().Create(ctx, port.CreateWebhookParams{
+		Webhook: domain.Webhook{URL: "https://93.184.216.34/hook", Events: []string{domain.WebhookEventArticleCreated}},
+	})
+	require.NotNil(t, err)
+
+	_, err = testService.Webhook().Create(ctx, port.CreateWebhookParams{
+		AuthArg: authArg,
+		Webhook: domain.Webhook{URL: "https://93.184.216.34/hook", Events: []string{"unknown"}},
+	})
+	fail, ok := err.(*exception.Exception)
+	require.True(t, ok)
+	require.Equal(t, exception.TypeValidation, fail.Type)
+
+	// private address refused
+	for _, url := range []string{"http://127.0.0.1:8080/hook", "http://169.254.169.254/latest", "http://10.0.0.1/hook", "http://[::1]/hook"} {
+		_, err = testService.Webhook().Create(ctx, port.CreateWebhookParams{
+			AuthArg: authArg,
+			Webhook: domain.Webhook{URL: url, Events: []string{domain.WebhookEventArticleCreated}},
+		})
+		fail, ok = err.(*exception.Exception)
+		require.True(t, ok)
+		require.Equal(t, exception.TypeValidation, fail.Type)
+		require.Contains(t, fail.Errors, "url")
+	}
+
+	webhook, err := testService.Webhook().Create(ctx, port.CreateWebhookParams{
+		AuthArg: authArg,
+		Webhook: domain.Webhook{URL: "https://93.184.216.34/hook", Events: []string{domain.WebhookEventArticleCreated}},
+	})
+	require.Nil(t, err)
+	require.NotEmpty(t, webhook.Secret)
+
+	webhooks, err := testService.Webhook().List(ctx, authArg)
+	require.Nil(t, err)
+	require.Len(t, webhooks, 1)
+	require.Equal(t, webhook.ID, webhooks[0].ID)
+
+	// other user cannot delete
+	_, otherAuth, _ := createRandomUser(t)
+	err = testService.Webhook().Delete(ctx, port.DeleteWebhookParams{AuthArg: otherAuth, WebhookID: webhook.ID})
+	fail, ok = err.(*exception.Exception)
+	require.True(t, ok)
+	require.Equal(t, exception.TypeNotFound, fail.Type)
+
+	err = testService.Webhook().Delete(ctx, port.DeleteWebhookParams{AuthArg: authArg, WebhookID: webhook.ID})
+	require.Nil(t, err)
+	webhooks, err = testService.Webhook().List(ctx, authArg)
+	require.Nil(t, err)
+	require.Len(t, webhooks, 0)
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	// receiver listen on loopback
+	config := testConfig
+	config.WebhookAllowPrivate = true
+	svc, err := service.NewService(config, testRepo, logger.NewLogger(config))
+	require.Nil(t, err)
+
+	author, authorAuth, _ := createRandomUser(t)
+	user, userAuth, _ := createRandomUser(t)
+	ctx := context.Background()
+	receiver, requests := webhookReceiver(t, http.StatusOK)
+
+	webhook, err := svc.Webhook().Create(ctx, port.CreateWebhookParams{
+		AuthArg: authorAuth,
+		Webhook: domain.Webhook{
+			URL:    receiver.URL,
+			Events: []string{domain.WebhookEventArticleCreated, domain.WebhookEventUserFollowed},
+		},
+	})
+	require.Nil(t, err)
+
+	article := createRandomArticle(t, author, authorAuth)
+	delivery := waitDelivery(t, svc, authorAuth, webhook.ID)
+	require.Equal(t, domain.WebhookDeliverySuccess, delivery.Status)
+	require.Equal(t, domain.WebhookEventArticleCreated, delivery.Event)
+	require.Equal(t, 1, delivery.Attempts)
+	require.Equal(t, http.StatusOK, delivery.ResponseCode)
+
+	received := requests()
+	require.Len(t, received, 1)
+	require.Equal(t, webhook.Sign(received[0].body), received[0].header.Get(domain.WebhookSignatureHeader))
+	require.Equal(t, domain.WebhookEventArticleCreated, received[0].header.Get(domain.WebhookEventHeader))
+	require.Equal(t, delivery.ID.String(), received[0].header.Get(domain.WebhookDeliveryHeader))
+
+	payload := struct {
+		Event string `json:"event"`
+		Data  struct {
+			Slug string `json:"slug"`
+		} `json:"data"`
+	}{}
+	require.Nil(t, json.Unmarshal(received[0].body, &payload))
+	require.Equal(t, domain.WebhookEventArticleCreated, payload.Event)
+	require.Equal(t, article.Slug, payload.Data.Slug)
+
+	// not subscribed event is not delivered
+	_, err = svc.Article().AddComment(ctx, port.AddCommentParams{
+		AuthArg: userAuth,
+		Slug:    article.Slug,
+		Comment: domain.Comment{Body: util.RandomString(10)},
+	})
+	require.Nil(t, err)
+
+	_, err = svc.User().Follow(ctx, port.ProfileParams{AuthArg: userAuth, Username: author.Username})
+	require.Nil(t, err)
+	delivery = waitDelivery(t, svc, authorAuth, webhook.ID)
+	require.Equal(t, domain.WebhookEventUserFollowed, delivery.Event)
+	require.NotEmpty(t, delivery.EventID)
+	require.Len(t, requests(), 2)
+
+	follow := struct {
+		Data struct {
+			Follower struct {
+				Username string `json:"username"`
+			} `json:"follower"`
+		} `json:"data"`
+	}{}
+	require.Nil(t, json.Unmarshal(requests()[1].body, &follow))
+	require.Equal(t, user.Username, follow.Data.Follower.Username)
+}
+
+func TestWebhookRetry(t *testing.T) {
+	config := testConfig
+	config.WebhookMaxAttempts = 3
+	config.WebhookAllowPrivate = true
+	config.OutboxPollInterval = 10 * time.Millisecond
+	svc, err := service.NewService(config, testRepo, logger.NewLogger(config))
+	require.Nil(t, err)
+
+	author, authorAuth, _ := createRandomUser(t)
+	ctx := context.Background()
+
+	// fail every attempt, then succeed on redelivery
+	receiver, requests := webhookReceiver(t, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusNoContent)
+	webhook, err := svc.Webhook().Create(ctx, port.CreateWebhookParams{
+		AuthArg: authorAuth,
+		Webhook: domain.Webhook{URL: receiver.URL, Events: []string{domain.WebhookEventArticleUpdated}},
+	})
+	require.Nil(t, err)
+
+	article := createRandomArticle(t, author, authorAuth)
+	_, err = svc.Article().Update(ctx, port.UpdateArticleParams{
+		AuthArg: authorAuth,
+		Slug:    article.Slug,
+		Article: domain.Article{Body: util.RandomString(20)},
+	})
+	require.Nil(t, err)
+
+	delivery := waitDelivery(t, svc, authorAuth, webhook.ID)
+	require.Equal(t, domain.WebhookDeliveryFailed, delivery.Status)
+	require.Equal(t, 3, delivery.Attempts)
+	require.Equal(t, http.StatusServiceUnavailable, delivery.ResponseCode)
+	require.NotEmpty(t, delivery.Error)
+	require.Len(t, requests(), 3)
+
+	// every attempt send same payload
+	for _, req := range requests() {
+		require.Equal(t, delivery.Payload, string(req.body))
+	}
+
+	redelivered, err := svc.Webhook().Redeliver(ctx, port.RedeliverWebhookParams{
+		AuthArg:    authorAuth,
+		WebhookID:  webhook.ID,
+		DeliveryID: delivery.ID,
+	})
+	require.Nil(t, err)
+	require.Equal(t, domain.WebhookDeliveryPending, redelivered.Status)
+
+	delivery = waitDelivery(t, svc, authorAuth, webhook.ID)
+	require.Equal(t, domain.WebhookDeliverySuccess, delivery.Status)
+	require.Equal(t, 4, delivery.Attempts)
+	require.Equal(t, http.StatusNoContent, delivery.ResponseCode)
+	require.Empty(t, delivery.Error)
+
+	// success delivery cannot be redelivered
+	_, err = svc.Webhook().Redeliver(ctx, port.RedeliverWebhookParams{
+		AuthArg:    authorAuth,
+		WebhookID:  webhook.ID,
+		DeliveryID: delivery.ID,
+	})
+	fail, ok := err.(*exception.Exception)
+	require.True(t, ok)
+	require.Equal(t, exception.TypeValidation, fail.Type)
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Backend store encoded values by key,
+// in-process LRU by default, shared store can be plugged later
+type Backend interface {
+	// Get return false when key missing or expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+type entry struct {
+	key       string
+	value     []byte
+	expiredAt time.Time
+}
+
+// LRU in-process backend, least recently used entry is evicted when full
+type LRU struct {
+	mu      sync.Mutex
+	size    int
+	list    *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+func NewLRU(size int) *LRU {
+	return &LRU{
+		size:    size,
+		list:    list.New(),
+		entries: map[string]*list.Element{},
+		now:     time.Now,
+	}
+}
+
+func (c *LRU) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	item := element.Value.(*entry)
+	if !item.expiredAt.IsZero() && !c.now().Before(item.expiredAt) {
+		c.remove(element)
+		return nil, false, nil
+	}
+	c.list.MoveToFront(element)
+	return item.value, true, nil
+}
+
+// Set store value, zero ttl never expire
+func (c *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiredAt time.Time
+	if ttl > 0 {
+		expiredAt = c.now().Add(ttl)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		item := element.Value.(*entry)
+		item.value, item.expiredAt = value, expiredAt
+		c.list.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.list.PushFront(&entry{key: key, value: value, expiredAt: expiredAt})
+	for c.list.Len() > c.size {
+		c.remove(c.list.Back())
+	}
+	return nil
+}
+
+func (c *LRU) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.remove(element)
+		}
+	}
+	return nil
+}
+
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.list.Len()
+}
+
+func (c *LRU) remove(element *list.Element) {
+	c.list.Remove(element)
+	delete(c.entries, element.Value.(*entry).key)
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUEvict(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2)
+
+	require.Nil(t, c.Set(ctx, "a", []byte("1"), 0))
+	require.Nil(t, c.Set(ctx, "b", []byte("2"), 0))
+
+	// a become most recently used, b evicted
+	value, ok, err := c.Get(ctx, "a")
+	require.Nil(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), value)
+
+	require.Nil(t, c.Set(ctx, "c", []byte("3"), 0))
+	require.Equal(t, 2, c.Len())
+
+	_, ok, _ = c.Get(ctx, "b")
+	require.False(t, ok)
+	_, ok, _ = c.Get(ctx, "a")
+	require.True(t, ok)
+	_, ok, _ = c.Get(ctx, "c")
+	require.True(t, ok)
+
+	// overwrite keep size
+	require.Nil(t, c.Set(ctx, "c", []byte("4"), 0))
+	value, _, _ = c.Get(ctx, "c")
+	require.Equal(t, []byte("4"), value)
+	require.Equal(t, 2, c.Len())
+}
+
+func TestLRUExpire(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	c := NewLRU(10)
+	c.now = func() time.Time { return now }
+
+	require.Nil(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	_, ok, _ := c.Get(ctx, "a")
+	require.True(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok, _ = c.Get(ctx, "a")
+	require.False(t, ok)
+	require.Equal(t, 0, c.Len())
+}
+
+func TestLRUDelete(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10)
+
+	require.Nil(t, c.Set(ctx, "a", []byte("1"), 0))
+	require.Nil(t, c.Set(ctx, "b", []byte("2"), 0))
+	require.Nil(t, c.Delete(ctx, "a", "missing"))
+
+	_, ok, _ := c.Get(ctx, "a")
+	require.False(t, ok)
+	_, ok, _ = c.Get(ctx, "b")
+	require.True(t, ok)
+}
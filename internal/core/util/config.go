@@ -1,12 +1,16 @@
 package util
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 const (
 	EnvProduction  = "production"
 	EnvDevelopment = "development"
+
+	DefaultShutdownTimeout = 5 * time.Second
 )
 
 type Config struct {
@@ -17,12 +21,41 @@ type Config struct {
 
 	ServerType string `mapstructure:"SERVER_TYPE"`
 	ServerPort int    `mapstructure:"SERVER_PORT"`
+	AdminPort  int    `mapstructure:"ADMIN_PORT"` // metrics and debug vars port, 0 serve metrics on restful server port
+	GrpcPort   int    `mapstructure:"GRPC_PORT"`  // grpc port when serve all or gateway, http use server port
+
+	ShutdownTimeout time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"` // drain active request before forced stop
 
 	LogType string `mapstructure:"LOG_TYPE"`
 	DBType  string `mapstructure:"DB_TYPE"`
 
 	TokenSymmetricKey string `mapstructure:"TOKEN_SYMMETRIC_KEY"`
 
+	WebhookMaxAttempts  int  `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookAllowPrivate bool `mapstructure:"WEBHOOK_ALLOW_PRIVATE"` // allow private address such as local receiver, development only
+
+	OutboxPollInterval time.Duration `mapstructure:"OUTBOX_POLL_INTERVAL"`
+	OutboxMaxAttempts  int           `mapstructure:"OUTBOX_MAX_ATTEMPTS"`
+
+	CacheSize int           `mapstructure:"CACHE_SIZE"` // max entries, 0 disable cache
+	CacheTTL  time.Duration `mapstructure:"CACHE_TTL"`
+
+	IdempotencyTTL   time.Duration `mapstructure:"IDEMPOTENCY_TTL"`   // how long response kept for replay
+	IdempotencyLease time.Duration `mapstructure:"IDEMPOTENCY_LEASE"` // processing key taken over after lease, few times of request duration
+
+	// requests per period of each route class, 0 disable limit
+	RateLimitAuth   int           `mapstructure:"RATE_LIMIT_AUTH"`
+	RateLimitWrite  int           `mapstructure:"RATE_LIMIT_WRITE"`
+	RateLimitRead   int           `mapstructure:"RATE_LIMIT_READ"`
+	RateLimitPeriod time.Duration `mapstructure:"RATE_LIMIT_PERIOD"`
+
+	CorsAllowedOrigins []string `mapstructure:"CORS_ALLOWED_ORIGINS"` // comma separated origin such as https://example.com, empty allow all except websocket
+	TrustedProxies     []string `mapstructure:"TRUSTED_PROXIES"`      // comma separated ip or cidr allowed to set X-Forwarded-For, empty trust none
+
+	TracingExporter    string  `mapstructure:"TRACING_EXPORTER"` // none, stdout or otlp
+	TracingEndpoint    string  `mapstructure:"TRACING_ENDPOINT"` // otlp http endpoint, empty use otel env
+	TracingSampleRatio float64 `mapstructure:"TRACING_SAMPLE_RATIO"`
+
 	TestRepo string `mapstructure:"TEST_REPO"`
 }
 
@@ -30,6 +63,14 @@ func (c Config) IsProduction() bool {
 	return c.Environment == EnvProduction
 }
 
+// DrainTimeout wait active request and connection on shutdown before forced stop
+func (c Config) DrainTimeout() time.Duration {
+	if c.ShutdownTimeout > 0 {
+		return c.ShutdownTimeout
+	}
+	return DefaultShutdownTimeout
+}
+
 func (c Config) IsTestAllRepo() bool {
 	return c.TestRepo == "all"
 }
@@ -1,12 +1,16 @@
 package exception
 
 const (
-	TypeInternal         = "ErrInternal"
-	TypeValidation       = "ErrValidation"
-	TypeNotFound         = "ErrNotFound"
-	TypePermissionDenied = "ErrPermissionDenied"
-	TypeTokenExpired     = "TokenExpired"
-	TypeTokenInvalid     = "TokenInvalid"
+	TypeInternal           = "ErrInternal"
+	TypeValidation         = "ErrValidation"
+	TypeNotFound           = "ErrNotFound"
+	TypePermissionDenied   = "ErrPermissionDenied"
+	TypePreconditionFailed = "ErrPreconditionFailed"
+	TypeConflict           = "ErrConflict"
+	TypeTooManyRequests    = "ErrTooManyRequests"
+	TypeUnavailable        = "ErrUnavailable"
+	TypeTokenExpired       = "TokenExpired"
+	TypeTokenInvalid       = "TokenInvalid"
 )
 
 type Err = map[string][]string
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "realworld"
+
+var (
+	requestTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "Handled requests by protocol, route and status.",
+	}, []string{"protocol", "route", "status"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Request latency by protocol, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"protocol", "route", "status"})
+
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database query latency by database and operation.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"db", "operation", "success"})
+
+	usersRegistered = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "users_registered_total",
+		Help:      "Registered users.",
+	})
+
+	articlesCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "articles_created_total",
+		Help:      "Created articles.",
+	})
+
+	commentsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "comments_created_total",
+		Help:      "Created comments.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(
+		requestTotal,
+		requestDuration,
+		queryDuration,
+		usersRegistered,
+		articlesCreated,
+		commentsCreated,
+	)
+}
+
+// Handler serve metrics in prometheus text format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+func ObserveRequest(protocol, route, status string, duration time.Duration) {
+	requestTotal.WithLabelValues(protocol, route, status).Inc()
+	requestDuration.WithLabelValues(protocol, route, status).Observe(duration.Seconds())
+}
+
+func ObserveQuery(db, operation string, success bool, duration time.Duration) {
+	label := "true"
+	if !success {
+		label = "false"
+	}
+	queryDuration.WithLabelValues(db, operation, label).Observe(duration.Seconds())
+}
+
+func UserRegistered() {
+	usersRegistered.Inc()
+}
+
+func ArticleCreated() {
+	articlesCreated.Inc()
+}
+
+func CommentCreated() {
+	commentsCreated.Inc()
+}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	ObserveRequest("http", "/articles/:slug", "200", 10*time.Millisecond)
+	ObserveQuery("postgres", "SELECT", true, time.Millisecond)
+	ArticleCreated()
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	body := recorder.Body.String()
+	require.Contains(t, body, `realworld_requests_total{protocol="http",route="/articles/:slug",status="200"} 1`)
+	require.Contains(t, body, `realworld_request_duration_seconds_bucket{protocol="http",route="/articles/:slug",status="200"`)
+	require.Contains(t, body, `realworld_db_query_duration_seconds_count{db="postgres",operation="SELECT",success="true"} 1`)
+	require.Contains(t, body, "realworld_articles_created_total 1")
+}
@@ -0,0 +1,112 @@
+package pubsub
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+)
+
+const (
+	DefaultHistorySize = 1000
+	DefaultBufferSize  = 64
+)
+
+// Broker in-process publish/subscribe of events.
+// Recent events are kept in history so subscriber can resume from last event id
+type Broker struct {
+	mu          sync.Mutex
+	seq         uint64
+	history     []domain.Event
+	historySize int
+	bufferSize  int
+	subs        map[*Subscription]struct{}
+}
+
+func NewBroker(historySize, bufferSize int) *Broker {
+	return &Broker{
+		historySize: historySize,
+		bufferSize:  bufferSize,
+		subs:        map[*Subscription]struct{}{},
+	}
+}
+
+// Publish assign event id and deliver event to matching subscribers.
+// Subscriber that cannot keep up is closed, it can resume using last event id
+func (b *Broker) Publish(event domain.Event) domain.Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	event.ID = strconv.FormatUint(b.seq, 10)
+
+	b.history = append(b.history, event)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for sub := range b.subs {
+		if !sub.match(event) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			b.remove(sub)
+		}
+	}
+	return event
+}
+
+// Subscribe receive events that match, replay events after lastEventID from history.
+// Unknown or empty lastEventID only receive new events
+func (b *Broker) Subscribe(lastEventID string, match func(domain.Event) bool) *Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	replay := []domain.Event{}
+	if last, err := strconv.ParseUint(lastEventID, 10, 64); err == nil && last < b.seq {
+		for _, event := range b.history {
+			if seq, _ := strconv.ParseUint(event.ID, 10, 64); seq > last && match(event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	sub := &Subscription{
+		broker: b,
+		match:  match,
+		ch:     make(chan domain.Event, b.bufferSize+len(replay)),
+	}
+	for _, event := range replay {
+		sub.ch <- event
+	}
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+// remove subscriber, caller must hold lock
+func (b *Broker) remove(sub *Subscription) {
+	if _, ok := b.subs[sub]; !ok {
+		return
+	}
+	delete(b.subs, sub)
+	close(sub.ch)
+}
+
+type Subscription struct {
+	broker *Broker
+	match  func(domain.Event) bool
+	ch     chan domain.Event
+}
+
+// Events channel closed when subscription closed or subscriber too slow
+func (sub *Subscription) Events() <-chan domain.Event {
+	return sub.ch
+}
+
+func (sub *Subscription) Close() {
+	sub.broker.mu.Lock()
+	defer sub.broker.mu.Unlock()
+	sub.broker.remove(sub)
+}
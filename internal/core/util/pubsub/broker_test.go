@@ -0,0 +1,79 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/labasubagia/realworld-backend/internal/core/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func all(domain.Event) bool { return true }
+
+func receive(t *testing.T, sub *Subscription, n int) []domain.Event {
+	events := []domain.Event{}
+	for i := 0; i < n; i++ {
+		select {
+		case event := <-sub.Events():
+			events = append(events, event)
+		default:
+			t.Fatalf("expected %d events, got %d", n, len(events))
+		}
+	}
+	return events
+}
+
+func TestBrokerPublish(t *testing.T) {
+	broker := NewBroker(10, 10)
+	userID := domain.NewID()
+
+	sub := broker.Subscribe("", func(event domain.Event) bool { return event.IsFor(userID) })
+	defer sub.Close()
+
+	broker.Publish(domain.NewEvent(domain.Event{Type: domain.EventTypeNotification, UserIDs: []domain.ID{domain.NewID()}}))
+	published := broker.Publish(domain.NewEvent(domain.Event{Type: domain.EventTypeNotification, UserIDs: []domain.ID{userID}}))
+	require.Equal(t, "2", published.ID)
+
+	events := receive(t, sub, 1)
+	require.Equal(t, published.ID, events[0].ID)
+	require.Len(t, sub.Events(), 0)
+}
+
+func TestBrokerResume(t *testing.T) {
+	broker := NewBroker(3, 10)
+	for i := 0; i < 5; i++ {
+		broker.Publish(domain.NewEvent(domain.Event{Type: domain.EventTypeArticle}))
+	}
+
+	// only events in history after last id
+	sub := broker.Subscribe("1", all)
+	events := receive(t, sub, 3)
+	require.Equal(t, []string{"3", "4", "5"}, []string{events[0].ID, events[1].ID, events[2].ID})
+	sub.Close()
+
+	sub = broker.Subscribe("4", all)
+	require.Equal(t, "5", receive(t, sub, 1)[0].ID)
+	sub.Close()
+
+	// up to date or invalid id has nothing to replay
+	for _, lastEventID := range []string{"", "5", "99", "abc"} {
+		sub = broker.Subscribe(lastEventID, all)
+		require.Len(t, sub.Events(), 0)
+		sub.Close()
+	}
+}
+
+func TestBrokerSlowSubscriber(t *testing.T) {
+	broker := NewBroker(10, 2)
+	sub := broker.Subscribe("", all)
+	for i := 0; i < 3; i++ {
+		broker.Publish(domain.NewEvent(domain.Event{Type: domain.EventTypeArticle}))
+	}
+
+	// buffered events still readable then channel closed
+	receive(t, sub, 2)
+	_, ok := <-sub.Events()
+	require.False(t, ok)
+
+	// close after removed is safe
+	sub.Close()
+}
@@ -0,0 +1,143 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+)
+
+// route class, each class has own limit
+const (
+	ClassAuth  = "auth"
+	ClassWrite = "write"
+	ClassRead  = "read"
+)
+
+const DefaultPeriod = time.Minute
+
+// Limit allow requests per period, zero requests is unlimited
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration // wait before next request allowed
+}
+
+// Store keep token bucket of each key,
+// in-process memory by default, shared store can be plugged for multiple instances
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+type Limiter struct {
+	store  Store
+	limits map[string]Limit
+}
+
+// New limiter with in-memory store and limits from config
+func New(config util.Config) *Limiter {
+	period := config.RateLimitPeriod
+	if period <= 0 {
+		period = DefaultPeriod
+	}
+	return NewLimiter(NewMemory(), map[string]Limit{
+		ClassAuth:  {Requests: config.RateLimitAuth, Period: period},
+		ClassWrite: {Requests: config.RateLimitWrite, Period: period},
+		ClassRead:  {Requests: config.RateLimitRead, Period: period},
+	})
+}
+
+func NewLimiter(store Store, limits map[string]Limit) *Limiter {
+	return &Limiter{
+		store:  store,
+		limits: limits,
+	}
+}
+
+// Allow take one token of key in class, class without limit always allowed
+func (l *Limiter) Allow(ctx context.Context, class, key string) (Result, error) {
+	limit, ok := l.limits[class]
+	if !ok || limit.Requests <= 0 || limit.Period <= 0 {
+		return Result{Allowed: true}, nil
+	}
+	return l.store.Take(ctx, class+":"+key, limit)
+}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	full      time.Time // bucket is full again, safe to drop
+}
+
+// Memory in-process token bucket store
+type Memory struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	sweptAt   time.Time
+	sweepEach time.Duration
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		buckets:   map[string]*bucket{},
+		now:       time.Now,
+		sweepEach: time.Minute,
+	}
+}
+
+func (m *Memory) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	capacity := float64(limit.Requests)
+	rate := capacity / limit.Period.Seconds() // token per second
+
+	item, ok := m.buckets[key]
+	if !ok {
+		item = &bucket{tokens: capacity, updatedAt: now}
+		m.buckets[key] = item
+	}
+	item.tokens = math.Min(capacity, item.tokens+now.Sub(item.updatedAt).Seconds()*rate)
+	item.updatedAt = now
+
+	result := Result{}
+	if item.tokens >= 1 {
+		item.tokens--
+		result.Allowed = true
+		result.Remaining = int(item.tokens)
+	} else {
+		result.RetryAfter = time.Duration((1 - item.tokens) / rate * float64(time.Second))
+	}
+	item.full = now.Add(time.Duration((capacity - item.tokens) / rate * float64(time.Second)))
+	return result, nil
+}
+
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.buckets)
+}
+
+// sweep drop full buckets, same as new bucket
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.sweptAt) < m.sweepEach {
+		return
+	}
+	m.sweptAt = now
+	for key, item := range m.buckets {
+		if !now.Before(item.full) {
+			delete(m.buckets, key)
+		}
+	}
+}
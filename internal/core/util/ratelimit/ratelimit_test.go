@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryTake(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemory()
+	store.now = func() time.Time { return now }
+	limit := Limit{Requests: 2, Period: time.Minute}
+
+	result, err := store.Take(ctx, "a", limit)
+	require.Nil(t, err)
+	require.True(t, result.Allowed)
+	require.Equal(t, 1, result.Remaining)
+
+	result, _ = store.Take(ctx, "a", limit)
+	require.True(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+
+	// bucket empty, one token refill every 30 seconds
+	result, _ = store.Take(ctx, "a", limit)
+	require.False(t, result.Allowed)
+	require.Equal(t, 30*time.Second, result.RetryAfter)
+
+	// other key has own bucket
+	result, _ = store.Take(ctx, "b", limit)
+	require.True(t, result.Allowed)
+
+	now = now.Add(30 * time.Second)
+	result, _ = store.Take(ctx, "a", limit)
+	require.True(t, result.Allowed)
+	result, _ = store.Take(ctx, "a", limit)
+	require.False(t, result.Allowed)
+}
+
+func TestMemorySweep(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemory()
+	store.now = func() time.Time { return now }
+	limit := Limit{Requests: 1, Period: time.Second}
+
+	store.Take(ctx, "a", limit)
+	store.Take(ctx, "b", limit)
+	require.Equal(t, 2, store.Len())
+
+	// refilled bucket dropped on next sweep
+	now = now.Add(2 * time.Minute)
+	store.Take(ctx, "c", limit)
+	require.Equal(t, 1, store.Len())
+}
+
+func TestLimiterAllow(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewLimiter(NewMemory(), map[string]Limit{
+		ClassAuth: {Requests: 1, Period: time.Minute},
+		ClassRead: {Requests: 0, Period: time.Minute},
+	})
+
+	result, err := limiter.Allow(ctx, ClassAuth, "ip:127.0.0.1")
+	require.Nil(t, err)
+	require.True(t, result.Allowed)
+	result, _ = limiter.Allow(ctx, ClassAuth, "ip:127.0.0.1")
+	require.False(t, result.Allowed)
+	require.Greater(t, result.RetryAfter, time.Duration(0))
+
+	// same key in other class not affected, zero or missing limit unlimited
+	for i := 0; i < 5; i++ {
+		result, _ = limiter.Allow(ctx, ClassRead, "ip:127.0.0.1")
+		require.True(t, result.Allowed)
+		result, _ = limiter.Allow(ctx, ClassWrite, "ip:127.0.0.1")
+		require.True(t, result.Allowed)
+	}
+}
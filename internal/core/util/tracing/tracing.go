@@ -0,0 +1,114 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+
+	serviceName = "realworld-backend"
+	tracerName  = "github.com/labasubagia/realworld-backend"
+)
+
+// Shutdown flush remaining spans
+type Shutdown func(context.Context) error
+
+// Setup register global tracer provider and w3c trace context propagator,
+// without exporter spans still carry trace id for log correlation
+func Setup(config util.Config) (Shutdown, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch config.TracingExporter {
+	case "", ExporterNone:
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		options := []otlptracehttp.Option{}
+		if config.TracingEndpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(config.TracingEndpoint))
+		}
+		exporter, err = otlptracehttp.New(context.Background(), options...)
+	default:
+		return nil, fmt.Errorf("tracing exporter %s not supported", config.TracingExporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := config.TracingSampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(serviceName),
+			attribute.String("server.type", config.ServerType),
+			attribute.String("db.type", config.DBType),
+		)),
+	}
+	if exporter != nil {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End record error of span then end it,
+// expected failure such as validation or not found is only recorded as attribute
+func End(span trace.Span, err error) {
+	if err != nil {
+		fail, ok := err.(*exception.Exception)
+		if ok && fail.Type != exception.TypeInternal {
+			span.SetAttributes(attribute.String("error.type", fail.Type))
+		} else {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+// Fail mark span as failed without error value, such as 5xx response
+func Fail(span trace.Span, message string) {
+	span.SetStatus(codes.Error, message)
+}
+
+// Extract continue trace of incoming carrier such as traceparent header
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// TraceID of context span, empty when no span
+func TraceID(ctx context.Context) string {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.HasTraceID() {
+		return ""
+	}
+	return spanCtx.TraceID().String()
+}
@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/labasubagia/realworld-backend/internal/core/util/exception"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup(t *testing.T) {
+	_, err := Setup(util.Config{TracingExporter: "unknown"})
+	require.NotNil(t, err)
+
+	shutdown, err := Setup(util.Config{TracingExporter: ExporterNone})
+	require.Nil(t, err)
+	require.Nil(t, shutdown(context.Background()))
+}
+
+func TestSpan(t *testing.T) {
+	_, err := Setup(util.Config{})
+	require.Nil(t, err)
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	// continue incoming trace
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := Extract(context.Background(), propagation.HeaderCarrier(header))
+
+	ctx, parent := Start(ctx, "parent")
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", TraceID(ctx))
+
+	_, expected := Start(ctx, "expected")
+	End(expected, exception.New(exception.TypeNotFound, "not found", nil))
+	_, failed := Start(ctx, "failed")
+	End(failed, errors.New("failed"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+	require.Equal(t, codes.Unset, spans[2].Status().Code)
+	require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+
+	require.Empty(t, TraceID(context.Background()))
+}
@@ -1,14 +1,48 @@
 package util
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/mail"
+	"net/netip"
 	"net/url"
 	"regexp"
 )
 
 var (
 	isValidUsername = regexp.MustCompile(`^[a-z0-9_]+$`).MatchString
+
+	// deniedPrefixes are special purpose ranges not reachable on public internet (RFC 6890),
+	// listed explicitly since net.IP helpers miss shared, benchmark and translation ranges
+	deniedPrefixes = []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),       // this network
+		netip.MustParsePrefix("10.0.0.0/8"),      // private
+		netip.MustParsePrefix("100.64.0.0/10"),   // shared address space, carrier-grade nat
+		netip.MustParsePrefix("127.0.0.0/8"),     // loopback
+		netip.MustParsePrefix("169.254.0.0/16"),  // link-local, cloud metadata
+		netip.MustParsePrefix("172.16.0.0/12"),   // private
+		netip.MustParsePrefix("192.0.0.0/24"),    // ietf protocol assignments
+		netip.MustParsePrefix("192.0.2.0/24"),    // documentation
+		netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast
+		netip.MustParsePrefix("192.168.0.0/16"),  // private
+		netip.MustParsePrefix("198.18.0.0/15"),   // benchmarking
+		netip.MustParsePrefix("198.51.100.0/24"), // documentation
+		netip.MustParsePrefix("203.0.113.0/24"),  // documentation
+		netip.MustParsePrefix("224.0.0.0/4"),     // multicast
+		netip.MustParsePrefix("240.0.0.0/4"),     // reserved and broadcast
+		netip.MustParsePrefix("::/128"),          // unspecified
+		netip.MustParsePrefix("::1/128"),         // loopback
+		netip.MustParsePrefix("64:ff9b::/96"),    // nat64 translation
+		netip.MustParsePrefix("64:ff9b:1::/48"),  // local nat64 translation
+		netip.MustParsePrefix("100::/64"),        // discard only
+		netip.MustParsePrefix("2001::/23"),       // ietf protocol assignments, teredo
+		netip.MustParsePrefix("2001:db8::/32"),   // documentation
+		netip.MustParsePrefix("2002::/16"),       // 6to4, may embed private ipv4
+		netip.MustParsePrefix("fc00::/7"),        // unique local
+		netip.MustParsePrefix("fe80::/10"),       // link-local
+		netip.MustParsePrefix("ff00::/8"),        // multicast
+	}
 )
 
 func ValidateString(value string, min, max int) error {
@@ -54,3 +88,47 @@ func ValidateURL(value string) error {
 	_, err := url.ParseRequestURI(value)
 	return err
 }
+
+// ValidateHTTPURL allow absolute http and https url only
+func ValidateHTTPURL(value string) error {
+	parsed, err := url.ParseRequestURI(value)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https")
+	}
+	if parsed.Hostname() == "" {
+		return fmt.Errorf("host required")
+	}
+	return nil
+}
+
+// IsPublicIP false for address in special purpose range, ipv4-mapped ipv6 checked as ipv4
+func IsPublicIP(ip net.IP) bool {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range deniedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidatePublicHost resolve host, every address must be public
+func ValidatePublicHost(ctx context.Context, host string) error {
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil || len(ips) == 0 {
+		return fmt.Errorf("host cannot be resolved")
+	}
+	for _, ip := range ips {
+		if !IsPublicIP(ip) {
+			return fmt.Errorf("host must not resolve to private address")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package util_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/labasubagia/realworld-backend/internal/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateHTTPURL(t *testing.T) {
+	require.Nil(t, util.ValidateHTTPURL("https://example.com/hook"))
+	require.Nil(t, util.ValidateHTTPURL("http://example.com:8080"))
+	require.NotNil(t, util.ValidateHTTPURL("example"))
+	require.NotNil(t, util.ValidateHTTPURL("ftp://example.com"))
+	require.NotNil(t, util.ValidateHTTPURL("file:///etc/passwd"))
+}
+
+func TestIsPublicIP(t *testing.T) {
+	for _, ip := range []string{
+		"93.184.216.34",
+		"8.8.8.8",
+		"100.63.255.255",
+		"100.128.0.0",
+		"198.17.255.255",
+		"198.20.0.0",
+		"1.0.0.0",
+		"2606:2800:220:1:248:1893:25c8:1946",
+		"64:ff9c::1",
+	} {
+		require.True(t, util.IsPublicIP(net.ParseIP(ip)), ip)
+	}
+	require.False(t, util.IsPublicIP(nil))
+}
+
+func TestIsPublicIPDenyRange(t *testing.T) {
+	testCases := []struct {
+		name string
+		ips  []string
+	}{
+		{"this network", []string{"0.0.0.0", "0.1.2.3", "0.255.255.255"}},
+		{"private", []string{"10.0.0.1", "10.255.255.255", "172.16.0.1", "172.31.255.255", "192.168.0.1", "192.168.255.255"}},
+		{"shared address space", []string{"100.64.0.0", "100.100.100.200", "100.127.255.255"}},
+		{"loopback", []string{"127.0.0.1", "127.255.255.254", "::1"}},
+		{"link-local", []string{"169.254.0.1", "169.254.169.254", "fe80::1", "febf:ffff::1"}},
+		{"ietf protocol assignments", []string{"192.0.0.1", "192.0.0.170", "2001::1", "2001:1ff::1"}},
+		{"documentation", []string{"192.0.2.1", "198.51.100.1", "203.0.113.1", "2001:db8::1"}},
+		{"6to4", []string{"192.88.99.1", "2002:a00:1::1"}},
+		{"benchmarking", []string{"198.18.0.1", "198.19.255.255"}},
+		{"multicast", []string{"224.0.0.1", "239.255.255.255", "ff02::1"}},
+		{"reserved and broadcast", []string{"240.0.0.1", "255.255.255.255"}},
+		{"unspecified", []string{"::"}},
+		{"nat64", []string{"64:ff9b::a00:1", "64:ff9b::808:808", "64:ff9b:1::1"}},
+		{"discard", []string{"100::1"}},
+		{"unique local", []string{"fc00::1", "fd00::1"}},
+		{"ipv4-mapped", []string{"::ffff:127.0.0.1", "::ffff:10.0.0.1", "::ffff:100.64.0.1"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, ip := range tc.ips {
+				parsed := net.ParseIP(ip)
+				require.NotNil(t, parsed, ip)
+				require.False(t, util.IsPublicIP(parsed), ip)
+			}
+		})
+	}
+}
+
+func TestValidatePublicHost(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, util.ValidatePublicHost(ctx, "93.184.216.34"))
+	require.NotNil(t, util.ValidatePublicHost(ctx, "127.0.0.1"))
+	require.NotNil(t, util.ValidatePublicHost(ctx, "169.254.169.254"))
+}